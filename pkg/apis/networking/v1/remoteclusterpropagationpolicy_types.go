@@ -0,0 +1,175 @@
+/*
+ Copyright 2021 The Hybridnet Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ResourceKind enumerates the local resource kinds a PropagationPolicy can
+// select on for export to remote clusters.
+type ResourceKind string
+
+const (
+	ResourceKindSubnet  ResourceKind = "Subnet"
+	ResourceKindNetwork ResourceKind = "Network"
+	ResourceKindVtep    ResourceKind = "Vtep"
+)
+
+// ResourceSelector picks the local-cluster objects a PropagationPolicy
+// applies to.
+type ResourceSelector struct {
+	// Kind of the local resource, one of Subnet, Network or Vtep.
+	Kind ResourceKind `json:"kind"`
+
+	// LabelSelector further narrows resources of Kind by label. An empty or
+	// nil selector matches every resource of Kind.
+	// +optional
+	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty"`
+
+	// NamespaceSelector narrows resources of Kind by namespace, for
+	// namespace-scoped kinds. Ignored for cluster-scoped kinds such as
+	// Subnet and Network.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+}
+
+// ClusterAffinity selects which joined RemoteClusters a resource should be
+// exported to.
+type ClusterAffinity struct {
+	// LabelSelector matches against each RemoteCluster's labels.
+	// +optional
+	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty"`
+
+	// ClusterNames, if non-empty, restricts the affinity to exactly these
+	// RemoteCluster names; LabelSelector is then not consulted.
+	// +optional
+	ClusterNames []string `json:"clusterNames,omitempty"`
+
+	// Exclude lists RemoteCluster names that should never receive resources
+	// from this policy, even if they otherwise match.
+	// +optional
+	Exclude []string `json:"exclude,omitempty"`
+}
+
+// SpreadConstraint caps how many matching clusters a single resource may be
+// propagated to.
+type SpreadConstraint struct {
+	// MaxClusters is the maximum number of clusters a single resource may be
+	// propagated to. Zero means unlimited.
+	// +optional
+	MaxClusters int32 `json:"maxClusters,omitempty"`
+}
+
+// Placement decides which RemoteClusters matching resources are propagated
+// to.
+type Placement struct {
+	// ClusterAffinity is required: a Placement with no affinity propagates
+	// to no cluster.
+	ClusterAffinity *ClusterAffinity `json:"clusterAffinity"`
+
+	// SpreadConstraints further bounds how many of the affine clusters are
+	// actually used.
+	// +optional
+	SpreadConstraints *SpreadConstraint `json:"spreadConstraints,omitempty"`
+}
+
+// RemoteClusterPropagationPolicySpec decides which local resources are
+// exported, as RemoteSubnet/RemoteVtep, into which RemoteClusters.
+type RemoteClusterPropagationPolicySpec struct {
+	ResourceSelectors []ResourceSelector `json:"resourceSelectors"`
+	Placement         Placement          `json:"placement"`
+}
+
+// AppliedResource records one (resource, cluster) tuple this policy is
+// currently responsible for, so users can debug why a subnet isn't showing
+// up in a peer cluster.
+type AppliedResource struct {
+	Kind         ResourceKind `json:"kind"`
+	Name         string       `json:"name"`
+	Namespace    string       `json:"namespace,omitempty"`
+	ClusterName  string       `json:"clusterName"`
+	Applied      bool         `json:"applied"`
+	Reason       string       `json:"reason,omitempty"`
+	LastSyncTime metav1.Time  `json:"lastSyncTime,omitempty"`
+}
+
+// RemoteClusterPropagationPolicyStatus reports the effect of a policy.
+type RemoteClusterPropagationPolicyStatus struct {
+	// AppliedResources lists every (resource, cluster) tuple this policy
+	// matched on its last sync; Applied tells whether propagation succeeded.
+	// +optional
+	AppliedResources []AppliedResource `json:"appliedResources,omitempty"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RemoteClusterPropagationPolicy is a namespace-scoped policy that selects
+// local Subnet/Network/Vtep objects and exports them as RemoteSubnet/
+// RemoteVtep objects into a chosen set of RemoteClusters.
+type RemoteClusterPropagationPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RemoteClusterPropagationPolicySpec   `json:"spec"`
+	Status RemoteClusterPropagationPolicyStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RemoteClusterPropagationPolicyList is a list of RemoteClusterPropagationPolicy.
+type RemoteClusterPropagationPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []RemoteClusterPropagationPolicy `json:"items"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterRemoteClusterPropagationPolicy is the cluster-scoped equivalent of
+// RemoteClusterPropagationPolicy, for selectors that should not be confined
+// to a single namespace.
+type ClusterRemoteClusterPropagationPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RemoteClusterPropagationPolicySpec   `json:"spec"`
+	Status RemoteClusterPropagationPolicyStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterRemoteClusterPropagationPolicyList is a list of ClusterRemoteClusterPropagationPolicy.
+type ClusterRemoteClusterPropagationPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ClusterRemoteClusterPropagationPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(
+		&RemoteClusterPropagationPolicy{},
+		&RemoteClusterPropagationPolicyList{},
+		&ClusterRemoteClusterPropagationPolicy{},
+		&ClusterRemoteClusterPropagationPolicyList{},
+	)
+}
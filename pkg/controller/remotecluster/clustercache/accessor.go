@@ -0,0 +1,294 @@
+/*
+ Copyright 2021 The Hybridnet Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package clustercache
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog"
+
+	kubeclientset "k8s.io/client-go/kubernetes"
+
+	"github.com/alibaba/hybridnet/pkg/client/clientset/versioned"
+	"github.com/alibaba/hybridnet/pkg/rcmanager"
+)
+
+const (
+	// healthProbeMinPeriod is the default per-cluster interval between
+	// Connected/Healthy probes once an accessor has connected successfully.
+	healthProbeMinPeriod = 30 * time.Second
+
+	backoffBase = 2 * time.Second
+	backoffMax  = 2 * time.Minute
+)
+
+// Condition is the Connected/Healthy state of a single remote cluster
+// accessor, together with the last time it was probed.
+type Condition struct {
+	Connected     bool
+	Healthy       bool
+	LastProbeTime time.Time
+	Reason        string
+}
+
+// Accessor lazily owns the kube+hybridnet clientsets, informer factory and
+// health-probe goroutine for a single RemoteCluster. It replaces the raw
+// *rcmanager.Manager that used to be stashed directly in
+// Controller.rcManagerCache.
+type Accessor struct {
+	clusterName string
+	config      *rcmanager.Config
+
+	onConditionChange func(clusterName string, condition Condition)
+
+	connectMu sync.Mutex
+	manager   *rcmanager.Manager
+	listers   map[Kind]interface{}
+
+	conditionMu sync.RWMutex
+	condition   Condition
+
+	healthStopCh chan struct{}
+	healthPeriod time.Duration
+
+	attempts int
+}
+
+func newAccessor(clusterName string, config *rcmanager.Config, onConditionChange func(string, Condition)) *Accessor {
+	return &Accessor{
+		clusterName:       clusterName,
+		config:            config,
+		onConditionChange: onConditionChange,
+		listers:           make(map[Kind]interface{}),
+		healthPeriod:      healthProbeMinPeriod,
+	}
+}
+
+// NewForTesting returns an Accessor that is already connected via kubeClient
+// and hybridClient, never dialing a real cluster. It exists so clustercache/fake
+// (and other test doubles) can exercise code that goes through GetAccessor/
+// Range instead of reaching into a bare map of clientsets.
+func NewForTesting(clusterName string, kubeClient kubeclientset.Interface, hybridClient versioned.Interface, onConditionChange func(string, Condition)) *Accessor {
+	a := newAccessor(clusterName, nil, onConditionChange)
+	a.manager = &rcmanager.Manager{KubeClient: kubeClient, HybridnetClient: hybridClient}
+	a.condition = Condition{Connected: true, Healthy: true, LastProbeTime: time.Now()}
+	return a
+}
+
+// SetConditionForTesting lets a test simulate a Connected/Healthy transition
+// (e.g. a probe failure) without driving it through Connect/probeHealth.
+func (a *Accessor) SetConditionForTesting(condition Condition) {
+	a.setCondition(condition)
+}
+
+// Connect returns the clientset pair, dialing the remote cluster if this is
+// the first call. Concurrent callers block on connectMu so only a single
+// dial is ever in flight for a given cluster.
+func (a *Accessor) Connect() (kubeclientset.Interface, versioned.Interface, error) {
+	a.connectMu.Lock()
+	defer a.connectMu.Unlock()
+
+	if a.manager != nil && a.isConnected() {
+		return a.manager.KubeClient, a.manager.HybridnetClient, nil
+	}
+
+	if remaining := a.backoffWait(); remaining > 0 {
+		return nil, nil, fmt.Errorf("clustercache: %s is backing off for %s after %d failed attempt(s)", a.clusterName, remaining, a.attempts)
+	}
+
+	manager, err := rcmanager.NewManager(a.config)
+	if err != nil {
+		a.recordFailure(err)
+		return nil, nil, err
+	}
+
+	a.manager = manager
+	a.attempts = 0
+	a.setCondition(Condition{Connected: true, Healthy: true, LastProbeTime: time.Now()})
+
+	if a.healthStopCh == nil {
+		a.healthStopCh = make(chan struct{})
+		go wait.Until(a.probeHealth, a.healthPeriod, a.healthStopCh)
+	}
+
+	return manager.KubeClient, manager.HybridnetClient, nil
+}
+
+// UpdateConfig replaces a's connection config if it differs from what a
+// already holds, and disconnects so the next Connect/Manager call redials
+// with the new config instead of keeping a connection (or a backoff) built
+// from a stale one. Returns whether config actually changed anything.
+func (a *Accessor) UpdateConfig(config *rcmanager.Config) bool {
+	a.connectMu.Lock()
+	if reflect.DeepEqual(a.config, config) {
+		a.connectMu.Unlock()
+		return false
+	}
+	a.config = config
+	a.attempts = 0
+	a.connectMu.Unlock()
+
+	a.DisconnectWithReason("connection config changed")
+	return true
+}
+
+// Manager returns the underlying *rcmanager.Manager for callers that have
+// not yet migrated off it, connecting first if necessary.
+func (a *Accessor) Manager() (*rcmanager.Manager, error) {
+	if _, _, err := a.Connect(); err != nil {
+		return nil, err
+	}
+	return a.manager, nil
+}
+
+// Condition returns a snapshot of the accessor's current health state.
+func (a *Accessor) Condition() Condition {
+	a.conditionMu.RLock()
+	defer a.conditionMu.RUnlock()
+	return a.condition
+}
+
+func (a *Accessor) Lister(kind Kind) (interface{}, error) {
+	a.connectMu.Lock()
+	defer a.connectMu.Unlock()
+
+	if lister, ok := a.listers[kind]; ok {
+		return lister, nil
+	}
+	return nil, fmt.Errorf("clustercache: %s has no informer started for kind %s, call Watch first", a.clusterName, kind)
+}
+
+func (a *Accessor) watch(kind Kind, handler cache.ResourceEventHandler) error {
+	a.connectMu.Lock()
+	defer a.connectMu.Unlock()
+
+	if a.manager == nil {
+		return fmt.Errorf("clustercache: %s is not connected yet", a.clusterName)
+	}
+
+	informer, lister, err := a.manager.InformerForKind(string(kind))
+	if err != nil {
+		return err
+	}
+	informer.AddEventHandler(handler)
+	a.listers[kind] = lister
+	return nil
+}
+
+func (a *Accessor) isConnected() bool {
+	return a.Condition().Connected
+}
+
+func (a *Accessor) probeHealth() {
+	a.connectMu.Lock()
+	manager := a.manager
+	a.connectMu.Unlock()
+
+	if manager == nil {
+		return
+	}
+
+	healthy, err := manager.Ping()
+	if err != nil || !healthy {
+		reason := "probe failed"
+		if err != nil {
+			reason = err.Error()
+		}
+		klog.Warningf("[cluster cache] %s became unhealthy: %s", a.clusterName, reason)
+		a.setCondition(Condition{Connected: false, Healthy: false, LastProbeTime: time.Now(), Reason: reason})
+		a.connectMu.Lock()
+		a.attempts++
+		a.connectMu.Unlock()
+		a.disconnect()
+		return
+	}
+
+	a.setCondition(Condition{Connected: true, Healthy: true, LastProbeTime: time.Now()})
+}
+
+// DisconnectWithReason closes the current connection, if any, without forgetting the
+// accessor: its Condition moves to disconnected and the next Connect/Manager
+// call redials from scratch. Use this to react to a cluster going unhealthy;
+// use ClusterCache.Remove instead when the RemoteCluster itself is gone.
+func (a *Accessor) DisconnectWithReason(reason string) {
+	a.setCondition(Condition{Connected: false, Healthy: false, LastProbeTime: time.Now(), Reason: reason})
+	a.disconnect()
+}
+
+// Disconnect is DisconnectWithReason with a generic reason.
+func (a *Accessor) Disconnect() {
+	a.DisconnectWithReason("disconnected")
+}
+
+func (a *Accessor) disconnect() {
+	a.connectMu.Lock()
+	manager := a.manager
+	a.manager = nil
+	a.listers = make(map[Kind]interface{})
+	stopCh := a.healthStopCh
+	a.healthStopCh = nil
+	a.connectMu.Unlock()
+
+	if stopCh != nil {
+		close(stopCh)
+	}
+	if manager != nil {
+		manager.Close()
+	}
+}
+
+func (a *Accessor) recordFailure(err error) {
+	a.attempts++
+	a.setCondition(Condition{Connected: false, Healthy: false, LastProbeTime: time.Now(), Reason: err.Error()})
+}
+
+func (a *Accessor) setCondition(condition Condition) {
+	a.conditionMu.Lock()
+	changed := a.condition.Connected != condition.Connected || a.condition.Healthy != condition.Healthy
+	a.condition = condition
+	a.conditionMu.Unlock()
+
+	if changed && a.onConditionChange != nil {
+		a.onConditionChange(a.clusterName, condition)
+	}
+}
+
+// backoffWait returns how much longer the caller must wait before retrying a
+// connect, based on the number of consecutive failed attempts, with jitter to
+// avoid every accessor retrying in lockstep after a shared outage.
+func (a *Accessor) backoffWait() time.Duration {
+	if a.attempts == 0 {
+		return 0
+	}
+
+	delay := backoffBase * time.Duration(1<<uint(a.attempts-1))
+	if delay > backoffMax {
+		delay = backoffMax
+	}
+	jittered := delay/2 + wait.Jitter(delay/2, 1.0)
+
+	elapsed := time.Since(a.Condition().LastProbeTime)
+	if elapsed >= jittered {
+		return 0
+	}
+	return jittered - elapsed
+}
@@ -0,0 +1,206 @@
+/*
+ Copyright 2021 The Hybridnet Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+// Package clustercache keeps a lazily-connected, health-tracked client/informer
+// pair for every joined RemoteCluster, modelled after Cluster API's
+// sigs.k8s.io/cluster-api/controllers/clustercache package. Callers that used
+// to reach into a *rcmanager.Manager stored in a bare sync.Map should instead
+// ask the ClusterCache for an Accessor and go through its exported methods.
+package clustercache
+
+import (
+	"fmt"
+	"sync"
+
+	kubeclientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog"
+
+	"github.com/alibaba/hybridnet/pkg/client/clientset/versioned"
+	"github.com/alibaba/hybridnet/pkg/rcmanager"
+)
+
+// Kind identifies the watchable resource types exposed through Watch/GetLister.
+type Kind string
+
+const (
+	KindRemoteSubnet Kind = "RemoteSubnet"
+	KindRemoteVtep   Kind = "RemoteVtep"
+)
+
+// ClusterCache owns one Accessor per joined RemoteCluster and is the single
+// place the remotecluster controller and its sibling reconcilers go through
+// to reach a peer cluster.
+type ClusterCache interface {
+	// GetClient returns the connected kube+hybridnet clientset pair for
+	// clusterName, dialing it on first use. Concurrent callers for the same
+	// cluster collapse onto a single dial.
+	GetClient(clusterName string) (kubeclientset.Interface, versioned.Interface, error)
+
+	// GetLister returns the informer lister for kind in clusterName. The
+	// caller is expected to type-assert the result, mirroring how a
+	// client-go GenericLister is used.
+	GetLister(clusterName string, kind Kind) (interface{}, error)
+
+	// Watch registers handler on the kind informer of clusterName, starting
+	// the accessor's informer factory if this is its first watcher.
+	Watch(clusterName string, kind Kind, handler cache.ResourceEventHandler) error
+
+	// GetAccessor returns the Accessor for clusterName, or false if none is
+	// currently registered (never created, or dropped by Remove/RemoveAll).
+	GetAccessor(clusterName string) (*Accessor, bool)
+
+	// GetOrCreateAccessor returns the Accessor for clusterName, creating and
+	// registering a new, not-yet-connected one from config if this is the
+	// first time clusterName is seen. If an Accessor already exists and
+	// config differs from what it was built with, it is reconfigured and
+	// disconnected so the next Connect redials with the new config, e.g.
+	// after a RemoteCluster's kubeconfig secret is rotated.
+	GetOrCreateAccessor(clusterName string, config *rcmanager.Config) (*Accessor, error)
+
+	// Remove disconnects and forgets clusterName, e.g. on RemoteCluster
+	// deletion.
+	Remove(clusterName string)
+
+	// Range iterates over all known accessors, in the style of sync.Map.Range.
+	// Iteration stops if f returns false.
+	Range(f func(clusterName string, accessor *Accessor) bool)
+
+	// RemoveAll disconnects and forgets every accessor, e.g. on controller
+	// shutdown.
+	RemoveAll()
+}
+
+type clusterCache struct {
+	mu        sync.RWMutex
+	accessors map[string]*Accessor
+
+	onConditionChange func(clusterName string, condition Condition)
+}
+
+// Options configures a ClusterCache.
+type Options struct {
+	// OnConditionChange, if set, is invoked every time an accessor's
+	// Connected/Healthy condition changes, so callers (e.g. the
+	// remotecluster controller) can re-enqueue the owning RemoteCluster
+	// instead of polling.
+	OnConditionChange func(clusterName string, condition Condition)
+}
+
+// New creates an empty ClusterCache. Accessors are created lazily via
+// GetOrCreateAccessor as RemoteClusters are observed.
+func New(opts Options) ClusterCache {
+	return &clusterCache{
+		accessors:         make(map[string]*Accessor),
+		onConditionChange: opts.OnConditionChange,
+	}
+}
+
+func (c *clusterCache) GetClient(clusterName string) (kubeclientset.Interface, versioned.Interface, error) {
+	accessor, ok := c.GetAccessor(clusterName)
+	if !ok {
+		return nil, nil, fmt.Errorf("clustercache: unknown cluster %q", clusterName)
+	}
+	return accessor.Connect()
+}
+
+func (c *clusterCache) GetLister(clusterName string, kind Kind) (interface{}, error) {
+	accessor, ok := c.GetAccessor(clusterName)
+	if !ok {
+		return nil, fmt.Errorf("clustercache: unknown cluster %q", clusterName)
+	}
+	if _, _, err := accessor.Connect(); err != nil {
+		return nil, err
+	}
+	return accessor.Lister(kind)
+}
+
+func (c *clusterCache) Watch(clusterName string, kind Kind, handler cache.ResourceEventHandler) error {
+	accessor, ok := c.GetAccessor(clusterName)
+	if !ok {
+		return fmt.Errorf("clustercache: unknown cluster %q", clusterName)
+	}
+	if _, _, err := accessor.Connect(); err != nil {
+		return err
+	}
+	return accessor.watch(kind, handler)
+}
+
+func (c *clusterCache) GetAccessor(clusterName string) (*Accessor, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	accessor, ok := c.accessors[clusterName]
+	return accessor, ok
+}
+
+func (c *clusterCache) GetOrCreateAccessor(clusterName string, config *rcmanager.Config) (*Accessor, error) {
+	c.mu.Lock()
+	if accessor, ok := c.accessors[clusterName]; ok {
+		c.mu.Unlock()
+		accessor.UpdateConfig(config)
+		return accessor, nil
+	}
+
+	accessor := newAccessor(clusterName, config, c.notifyConditionChange)
+	c.accessors[clusterName] = accessor
+	c.mu.Unlock()
+
+	return accessor, nil
+}
+
+func (c *clusterCache) Remove(clusterName string) {
+	c.mu.Lock()
+	accessor, ok := c.accessors[clusterName]
+	delete(c.accessors, clusterName)
+	c.mu.Unlock()
+
+	if ok {
+		accessor.disconnect()
+	}
+}
+
+func (c *clusterCache) Range(f func(clusterName string, accessor *Accessor) bool) {
+	c.mu.RLock()
+	snapshot := make(map[string]*Accessor, len(c.accessors))
+	for name, accessor := range c.accessors {
+		snapshot[name] = accessor
+	}
+	c.mu.RUnlock()
+
+	for name, accessor := range snapshot {
+		if !f(name, accessor) {
+			return
+		}
+	}
+}
+
+func (c *clusterCache) RemoveAll() {
+	c.mu.Lock()
+	accessors := c.accessors
+	c.accessors = make(map[string]*Accessor)
+	c.mu.Unlock()
+
+	for name, accessor := range accessors {
+		klog.V(4).Infof("[cluster cache] closing accessor for cluster %s", name)
+		accessor.disconnect()
+	}
+}
+
+func (c *clusterCache) notifyConditionChange(clusterName string, condition Condition) {
+	if c.onConditionChange != nil {
+		c.onConditionChange(clusterName, condition)
+	}
+}
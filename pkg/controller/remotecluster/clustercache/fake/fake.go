@@ -0,0 +1,163 @@
+/*
+ Copyright 2021 The Hybridnet Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+// Package fake provides an in-memory clustercache.ClusterCache for tests that
+// exercise the remotecluster controller without dialing real clusters.
+package fake
+
+import (
+	"fmt"
+	"sync"
+
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+
+	kubeclientset "k8s.io/client-go/kubernetes"
+
+	"github.com/alibaba/hybridnet/pkg/client/clientset/versioned"
+	hybridnetfake "github.com/alibaba/hybridnet/pkg/client/clientset/versioned/fake"
+	"github.com/alibaba/hybridnet/pkg/controller/remotecluster/clustercache"
+	"github.com/alibaba/hybridnet/pkg/rcmanager"
+)
+
+// ClusterCache is a clustercache.ClusterCache backed by fake clientsets and
+// real *clustercache.Accessor instances (via clustercache.NewForTesting), with
+// every cluster pre-registered as connected and healthy. Accessors being real
+// means callers that go through GetAccessor/Range -- health.go's
+// renewRemoteLeases/checkRemoteLeaseExpiry, controller.go's EventUpdateStatus
+// handling -- are exercised the same way they are against a live ClusterCache.
+type ClusterCache struct {
+	mu        sync.RWMutex
+	kube      map[string]kubeclientset.Interface
+	hybrid    map[string]versioned.Interface
+	accessors map[string]*clustercache.Accessor
+
+	// OnConditionChange mirrors clustercache.Options.OnConditionChange, so
+	// tests can assert a RemoteCluster gets re-enqueued on SetHealthy just
+	// like it would against a real ClusterCache.
+	OnConditionChange func(clusterName string, condition clustercache.Condition)
+}
+
+// New returns an empty fake ClusterCache.
+func New() *ClusterCache {
+	return &ClusterCache{
+		kube:      make(map[string]kubeclientset.Interface),
+		hybrid:    make(map[string]versioned.Interface),
+		accessors: make(map[string]*clustercache.Accessor),
+	}
+}
+
+// AddCluster registers clusterName as connected and healthy, backed by fresh
+// fake clientsets and a real Accessor.
+func (f *ClusterCache) AddCluster(clusterName string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	kube := kubefake.NewSimpleClientset()
+	hybrid := hybridnetfake.NewSimpleClientset()
+	f.kube[clusterName] = kube
+	f.hybrid[clusterName] = hybrid
+	f.accessors[clusterName] = clustercache.NewForTesting(clusterName, kube, hybrid, func(name string, condition clustercache.Condition) {
+		if f.OnConditionChange != nil {
+			f.OnConditionChange(name, condition)
+		}
+	})
+}
+
+func (f *ClusterCache) GetClient(clusterName string) (kubeclientset.Interface, versioned.Interface, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	kube, ok := f.kube[clusterName]
+	if !ok {
+		return nil, nil, fmt.Errorf("fake clustercache: unknown cluster %q", clusterName)
+	}
+	return kube, f.hybrid[clusterName], nil
+}
+
+func (f *ClusterCache) GetLister(_ string, _ clustercache.Kind) (interface{}, error) {
+	return nil, fmt.Errorf("fake clustercache: GetLister is not supported, use AddCluster and the fake clientset directly")
+}
+
+func (f *ClusterCache) Watch(string, clustercache.Kind, cache.ResourceEventHandler) error {
+	return nil
+}
+
+func (f *ClusterCache) GetAccessor(clusterName string) (*clustercache.Accessor, bool) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	accessor, ok := f.accessors[clusterName]
+	return accessor, ok
+}
+
+func (f *ClusterCache) GetOrCreateAccessor(clusterName string, _ *rcmanager.Config) (*clustercache.Accessor, error) {
+	return nil, fmt.Errorf("fake clustercache: accessors are not constructible in tests, use AddCluster instead")
+}
+
+func (f *ClusterCache) Remove(clusterName string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.kube, clusterName)
+	delete(f.hybrid, clusterName)
+	delete(f.accessors, clusterName)
+}
+
+func (f *ClusterCache) Range(rangeFunc func(clusterName string, accessor *clustercache.Accessor) bool) {
+	f.mu.RLock()
+	snapshot := make(map[string]*clustercache.Accessor, len(f.accessors))
+	for name, accessor := range f.accessors {
+		snapshot[name] = accessor
+	}
+	f.mu.RUnlock()
+
+	for name, accessor := range snapshot {
+		if !rangeFunc(name, accessor) {
+			return
+		}
+	}
+}
+
+func (f *ClusterCache) RemoveAll() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.kube = make(map[string]kubeclientset.Interface)
+	f.hybrid = make(map[string]versioned.Interface)
+	f.accessors = make(map[string]*clustercache.Accessor)
+}
+
+// SetHealthy lets a test simulate an accessor becoming unhealthy, updating
+// the Accessor's real Condition (and firing OnConditionChange) rather than a
+// side map the rest of the fake's accessors can't see.
+func (f *ClusterCache) SetHealthy(clusterName string, healthy bool) {
+	f.mu.RLock()
+	accessor, ok := f.accessors[clusterName]
+	f.mu.RUnlock()
+	if !ok {
+		return
+	}
+	accessor.SetConditionForTesting(clustercache.Condition{Connected: healthy, Healthy: healthy, LastProbeTime: accessor.Condition().LastProbeTime})
+}
+
+// IsHealthy reports the last health state set for clusterName.
+func (f *ClusterCache) IsHealthy(clusterName string) bool {
+	f.mu.RLock()
+	accessor, ok := f.accessors[clusterName]
+	f.mu.RUnlock()
+	if !ok {
+		return false
+	}
+	return accessor.Condition().Healthy
+}
+
+var _ clustercache.ClusterCache = (*ClusterCache)(nil)
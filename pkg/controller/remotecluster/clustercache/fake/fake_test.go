@@ -0,0 +1,128 @@
+/*
+ Copyright 2021 The Hybridnet Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package fake
+
+import (
+	"testing"
+
+	"github.com/alibaba/hybridnet/pkg/controller/remotecluster/clustercache"
+)
+
+func TestClusterCacheAddAndGetClient(t *testing.T) {
+	cache := New()
+
+	if _, _, err := cache.GetClient("cluster-a"); err == nil {
+		t.Fatal("expected an error for a cluster that was never added")
+	}
+
+	cache.AddCluster("cluster-a")
+
+	kube, hybrid, err := cache.GetClient("cluster-a")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if kube == nil || hybrid == nil {
+		t.Fatal("expected non-nil fake clientsets for a registered cluster")
+	}
+}
+
+func TestClusterCacheSetAndIsHealthy(t *testing.T) {
+	cache := New()
+	cache.AddCluster("cluster-a")
+
+	if !cache.IsHealthy("cluster-a") {
+		t.Fatal("expected a freshly added cluster to start healthy")
+	}
+
+	cache.SetHealthy("cluster-a", false)
+	if cache.IsHealthy("cluster-a") {
+		t.Fatal("expected IsHealthy to reflect SetHealthy(false)")
+	}
+}
+
+func TestClusterCacheRemoveAndRemoveAll(t *testing.T) {
+	cache := New()
+	cache.AddCluster("cluster-a")
+	cache.AddCluster("cluster-b")
+
+	cache.Remove("cluster-a")
+	if _, _, err := cache.GetClient("cluster-a"); err == nil {
+		t.Fatal("expected cluster-a to be gone after Remove")
+	}
+	if _, _, err := cache.GetClient("cluster-b"); err != nil {
+		t.Fatalf("expected cluster-b to be unaffected by Remove(cluster-a): %v", err)
+	}
+
+	cache.RemoveAll()
+	if _, _, err := cache.GetClient("cluster-b"); err == nil {
+		t.Fatal("expected cluster-b to be gone after RemoveAll")
+	}
+}
+
+func TestClusterCacheGetAccessorAndRange(t *testing.T) {
+	cache := New()
+
+	if _, ok := cache.GetAccessor("cluster-a"); ok {
+		t.Fatal("expected no accessor for a cluster that was never added")
+	}
+
+	cache.AddCluster("cluster-a")
+	cache.AddCluster("cluster-b")
+
+	accessor, ok := cache.GetAccessor("cluster-a")
+	if !ok || accessor == nil {
+		t.Fatal("expected a real accessor for a registered cluster")
+	}
+	if !accessor.Condition().Connected || !accessor.Condition().Healthy {
+		t.Fatal("expected a freshly added cluster's accessor to start connected and healthy")
+	}
+
+	seen := make(map[string]bool)
+	cache.Range(func(clusterName string, a *clustercache.Accessor) bool {
+		seen[clusterName] = true
+		return true
+	})
+	if !seen["cluster-a"] || !seen["cluster-b"] {
+		t.Fatalf("expected Range to visit both registered clusters, got %v", seen)
+	}
+}
+
+func TestClusterCacheSetHealthyNotifiesOnConditionChange(t *testing.T) {
+	cache := New()
+	var notified string
+	cache.OnConditionChange = func(clusterName string, condition clustercache.Condition) {
+		notified = clusterName
+	}
+	cache.AddCluster("cluster-a")
+
+	cache.SetHealthy("cluster-a", false)
+
+	if cache.IsHealthy("cluster-a") {
+		t.Fatal("expected IsHealthy to reflect SetHealthy(false)")
+	}
+	if notified != "cluster-a" {
+		t.Fatalf("expected OnConditionChange to fire for cluster-a, got %q", notified)
+	}
+
+	accessor, ok := cache.GetAccessor("cluster-a")
+	if !ok {
+		t.Fatal("expected accessor to still exist after SetHealthy")
+	}
+	if accessor.Condition().Healthy {
+		t.Fatal("expected the accessor's own Condition to reflect SetHealthy(false)")
+	}
+}
@@ -0,0 +1,313 @@
+/*
+ Copyright 2021 The Hybridnet Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+// Package clusterprofile mirrors every networkingv1.RemoteCluster into a
+// multicluster.x-k8s.io/v1alpha1 ClusterProfile, so hybridnet-connected
+// clusters become discoverable by generic multi-cluster tooling (service
+// export/import, scheduler webhooks, etc.) without those tools needing to
+// understand hybridnet's own CRDs.
+package clusterprofile
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog"
+
+	clusterprofilev1alpha1 "sigs.k8s.io/about-api/apis/v1alpha1"
+	clusterprofileclientset "sigs.k8s.io/about-api/generated/clientset/versioned"
+	clusterprofileinformers "sigs.k8s.io/about-api/generated/informers/externalversions/apis/v1alpha1"
+	clusterprofilelisters "sigs.k8s.io/about-api/generated/listers/apis/v1alpha1"
+
+	networkingv1 "github.com/alibaba/hybridnet/pkg/apis/networking/v1"
+	"github.com/alibaba/hybridnet/pkg/client/clientset/versioned"
+	informers "github.com/alibaba/hybridnet/pkg/client/informers/externalversions/networking/v1"
+	listers "github.com/alibaba/hybridnet/pkg/client/listers/networking/v1"
+)
+
+const (
+	ControllerName = "clusterprofile"
+
+	// OwnerRemoteClusterLabel records which RemoteCluster a ClusterProfile
+	// was generated from, and is what the filtered ClusterProfile informer
+	// selects on.
+	OwnerRemoteClusterLabel = "multicluster.hybridnet.io/owner-remote-cluster"
+
+	ConditionControlPlaneHealthy = "ControlPlaneHealthy"
+	ConditionJoined              = "Joined"
+)
+
+// Controller reconciles RemoteCluster objects into ClusterProfile objects.
+// It is only ever started when the ClusterProfile CRD is discoverable on the
+// local apiserver; on clusters without the inventory API installed, hybridnet
+// runs with this subsystem entirely disabled.
+type Controller struct {
+	namespace string
+
+	hybridnetClient      versioned.Interface
+	clusterProfileClient clusterprofileclientset.Interface
+	remoteClusterLister  listers.RemoteClusterLister
+	remoteClusterSynced  cache.InformerSynced
+	clusterProfileLister clusterprofilelisters.ClusterProfileLister
+	clusterProfileSynced cache.InformerSynced
+	queue                workqueue.RateLimitingInterface
+}
+
+// NewController wires informer event handlers for both RemoteCluster and
+// ClusterProfile (filtered to ones owned by a RemoteCluster) into a single
+// workqueue keyed by RemoteCluster name.
+func NewController(
+	hybridnetClient versioned.Interface,
+	clusterProfileClient clusterprofileclientset.Interface,
+	remoteClusterInformer informers.RemoteClusterInformer,
+	clusterProfileInformer clusterprofileinformers.ClusterProfileInformer,
+	namespace string,
+) *Controller {
+	c := &Controller{
+		namespace:            namespace,
+		hybridnetClient:      hybridnetClient,
+		clusterProfileClient: clusterProfileClient,
+		remoteClusterLister:  remoteClusterInformer.Lister(),
+		remoteClusterSynced:  remoteClusterInformer.Informer().HasSynced,
+		clusterProfileLister: clusterProfileInformer.Lister(),
+		clusterProfileSynced: clusterProfileInformer.Informer().HasSynced,
+		queue:                workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName),
+	}
+
+	remoteClusterInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.enqueueRemoteCluster,
+		UpdateFunc: func(_, newObj interface{}) { c.enqueueRemoteCluster(newObj) },
+		DeleteFunc: c.enqueueRemoteCluster,
+	})
+
+	clusterProfileInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: func(obj interface{}) bool {
+			profile, ok := obj.(*clusterprofilev1alpha1.ClusterProfile)
+			if !ok {
+				return false
+			}
+			_, owned := profile.Labels[OwnerRemoteClusterLabel]
+			return owned
+		},
+		Handler: cache.ResourceEventHandlerFuncs{
+			AddFunc:    c.enqueueOwningRemoteCluster,
+			UpdateFunc: func(_, newObj interface{}) { c.enqueueOwningRemoteCluster(newObj) },
+			DeleteFunc: c.enqueueOwningRemoteCluster,
+		},
+	})
+
+	return c
+}
+
+// IsClusterProfileCRDAvailable probes the apiserver's discovery API for the
+// multicluster.x-k8s.io/v1alpha1 group/version, so callers can decide whether
+// to start this controller at all.
+func IsClusterProfileCRDAvailable(discoveryClient discovery.DiscoveryInterface) bool {
+	_, err := discoveryClient.ServerResourcesForGroupVersion(clusterprofilev1alpha1.GroupVersion.String())
+	if err != nil {
+		klog.V(4).Infof("[cluster profile] %s/%s not discoverable: %v", clusterprofilev1alpha1.GroupVersion.String(), "ClusterProfile", err)
+		return false
+	}
+	return true
+}
+
+func (c *Controller) enqueueRemoteCluster(obj interface{}) {
+	rc, ok := obj.(*networkingv1.RemoteCluster)
+	if !ok {
+		return
+	}
+	c.queue.Add(rc.Name)
+}
+
+// enqueueOwningRemoteCluster re-queues the RemoteCluster that owns a
+// ClusterProfile whenever that ClusterProfile itself is added, edited or
+// deleted, so drift introduced by something other than this controller gets
+// reconciled back instead of only being noticed on the next RemoteCluster
+// event.
+func (c *Controller) enqueueOwningRemoteCluster(obj interface{}) {
+	profile, ok := obj.(*clusterprofilev1alpha1.ClusterProfile)
+	if !ok {
+		return
+	}
+	if name, owned := profile.Labels[OwnerRemoteClusterLabel]; owned {
+		c.queue.Add(name)
+	}
+}
+
+func (c *Controller) Run(workers int, stopCh <-chan struct{}) error {
+	defer utilruntime.HandleCrash()
+	defer c.queue.ShutDown()
+
+	klog.Infof("Starting %s controller", ControllerName)
+	if ok := cache.WaitForCacheSync(stopCh, c.remoteClusterSynced, c.clusterProfileSynced); !ok {
+		return fmt.Errorf("%s failed to wait for caches to sync", ControllerName)
+	}
+
+	for i := 0; i < workers; i++ {
+		go wait.Until(c.runWorker, time.Second, stopCh)
+	}
+
+	<-stopCh
+	klog.Info("Shutting down clusterprofile workers")
+	return nil
+}
+
+func (c *Controller) runWorker() {
+	for c.processNextWorkItem() {
+	}
+}
+
+func (c *Controller) processNextWorkItem() bool {
+	key, shutdown := c.queue.Get()
+	if shutdown {
+		return false
+	}
+	defer c.queue.Done(key)
+
+	if err := c.sync(key.(string)); err != nil {
+		c.queue.AddRateLimited(key)
+		utilruntime.HandleError(fmt.Errorf("[cluster profile] sync %q failed: %w", key, err))
+		return true
+	}
+
+	c.queue.Forget(key)
+	return true
+}
+
+// sync mirrors the RemoteCluster named name into a ClusterProfile, deleting
+// the ClusterProfile if the RemoteCluster no longer exists.
+func (c *Controller) sync(name string) error {
+	remoteCluster, err := c.remoteClusterLister.Get(name)
+	if apierrors.IsNotFound(err) {
+		return c.deleteClusterProfile(name)
+	}
+	if err != nil {
+		return err
+	}
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		return c.applyClusterProfile(remoteCluster)
+	})
+}
+
+func (c *Controller) deleteClusterProfile(remoteClusterName string) error {
+	err := c.clusterProfileClient.MulticlusterV1alpha1().ClusterProfiles(c.namespace).Delete(context.TODO(), remoteClusterName, metav1.DeleteOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+func (c *Controller) applyClusterProfile(remoteCluster *networkingv1.RemoteCluster) error {
+	existing, err := c.clusterProfileLister.ClusterProfiles(c.namespace).Get(remoteCluster.Name)
+	switch {
+	case apierrors.IsNotFound(err):
+		desired := buildClusterProfile(remoteCluster, c.namespace)
+		_, err = c.clusterProfileClient.MulticlusterV1alpha1().ClusterProfiles(c.namespace).Create(context.TODO(), desired, metav1.CreateOptions{})
+		return err
+	case err != nil:
+		return err
+	}
+
+	updated := existing.DeepCopy()
+	if !applyClusterProfileStatus(remoteCluster, updated) {
+		return nil
+	}
+	_, err = c.clusterProfileClient.MulticlusterV1alpha1().ClusterProfiles(c.namespace).UpdateStatus(context.TODO(), updated, metav1.UpdateOptions{})
+	return err
+}
+
+func buildClusterProfile(remoteCluster *networkingv1.RemoteCluster, namespace string) *clusterprofilev1alpha1.ClusterProfile {
+	profile := &clusterprofilev1alpha1.ClusterProfile{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      remoteCluster.Name,
+			Namespace: namespace,
+			Labels: map[string]string{
+				OwnerRemoteClusterLabel: remoteCluster.Name,
+			},
+		},
+		Spec: clusterprofilev1alpha1.ClusterProfileSpec{
+			ClusterManager: clusterprofilev1alpha1.ClusterManager{Name: "hybridnet"},
+			DisplayName:    remoteCluster.Name,
+		},
+	}
+	applyClusterProfileStatus(remoteCluster, profile)
+	return profile
+}
+
+// applyClusterProfileStatus merges the conditions and credential providers
+// derived from remoteCluster into profile's status in place, and reports
+// whether anything actually changed. Conditions are merged with
+// apimeta.SetStatusCondition rather than replaced wholesale, so a sync that
+// doesn't flip Joined/ControlPlaneHealthy doesn't also bump their
+// LastTransitionTime, and a sync where nothing changed at all skips the
+// UpdateStatus call entirely.
+func applyClusterProfileStatus(remoteCluster *networkingv1.RemoteCluster, profile *clusterprofilev1alpha1.ClusterProfile) bool {
+	changed := false
+
+	if apimeta.SetStatusCondition(&profile.Status.Conditions, newCondition(ConditionJoined, len(remoteCluster.Status.UUID) > 0, "RemoteClusterUUIDAssigned", "RemoteClusterUUIDPending")) {
+		changed = true
+	}
+	if apimeta.SetStatusCondition(&profile.Status.Conditions, newCondition(ConditionControlPlaneHealthy, remoteCluster.Status.Status == networkingv1.ClusterReady, "RemoteClusterReady", "RemoteClusterNotReady")) {
+		changed = true
+	}
+
+	desiredProviders := credentialProvidersFor(remoteCluster)
+	if !reflect.DeepEqual(profile.Status.CredentialProviders, desiredProviders) {
+		profile.Status.CredentialProviders = desiredProviders
+		changed = true
+	}
+
+	return changed
+}
+
+func credentialProvidersFor(remoteCluster *networkingv1.RemoteCluster) []clusterprofilev1alpha1.CredentialProvider {
+	if len(remoteCluster.Spec.CredentialProviders) == 0 {
+		return nil
+	}
+
+	providers := make([]clusterprofilev1alpha1.CredentialProvider, 0, len(remoteCluster.Spec.CredentialProviders))
+	for _, provider := range remoteCluster.Spec.CredentialProviders {
+		providers = append(providers, clusterprofilev1alpha1.CredentialProvider{Name: provider})
+	}
+	return providers
+}
+
+func newCondition(conditionType string, ok bool, trueReason, falseReason string) metav1.Condition {
+	status := metav1.ConditionFalse
+	reason := falseReason
+	if ok {
+		status = metav1.ConditionTrue
+		reason = trueReason
+	}
+	return metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		Reason:             reason,
+		LastTransitionTime: metav1.Now(),
+	}
+}
@@ -0,0 +1,105 @@
+/*
+ Copyright 2021 The Hybridnet Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package clusterprofile
+
+import (
+	"testing"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	clusterprofilev1alpha1 "sigs.k8s.io/about-api/apis/v1alpha1"
+
+	networkingv1 "github.com/alibaba/hybridnet/pkg/apis/networking/v1"
+)
+
+func TestBuildClusterProfile(t *testing.T) {
+	remoteCluster := &networkingv1.RemoteCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-a"},
+		Status:     networkingv1.RemoteClusterStatus{UUID: "uuid-a", Status: networkingv1.ClusterReady},
+	}
+
+	profile := buildClusterProfile(remoteCluster, "multicluster-system")
+
+	if profile.Name != "cluster-a" || profile.Namespace != "multicluster-system" {
+		t.Fatalf("expected the ClusterProfile to be named/namespaced after the RemoteCluster, got %s/%s", profile.Namespace, profile.Name)
+	}
+	if profile.Labels[OwnerRemoteClusterLabel] != "cluster-a" {
+		t.Fatalf("expected %s to be stamped with OwnerRemoteClusterLabel, got %v", profile.Name, profile.Labels)
+	}
+	if profile.Spec.DisplayName != "cluster-a" {
+		t.Fatalf("expected DisplayName to default to the RemoteCluster name, got %q", profile.Spec.DisplayName)
+	}
+
+	joined := apimeta.FindStatusCondition(profile.Status.Conditions, ConditionJoined)
+	if joined == nil || joined.Status != metav1.ConditionTrue {
+		t.Fatalf("expected a freshly built profile for an already-joined cluster to carry Joined=True, got %v", joined)
+	}
+}
+
+func TestApplyClusterProfileStatusReportsNoChangeOnceConverged(t *testing.T) {
+	remoteCluster := &networkingv1.RemoteCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-a"},
+		Status:     networkingv1.RemoteClusterStatus{UUID: "uuid-a", Status: networkingv1.ClusterReady},
+	}
+	profile := &clusterprofilev1alpha1.ClusterProfile{}
+
+	if !applyClusterProfileStatus(remoteCluster, profile) {
+		t.Fatal("expected the first apply against an empty status to report a change")
+	}
+	if applyClusterProfileStatus(remoteCluster, profile) {
+		t.Fatal("expected a second apply with nothing different upstream to report no change")
+	}
+}
+
+func TestApplyClusterProfileStatusReflectsUUIDAndReadiness(t *testing.T) {
+	remoteCluster := &networkingv1.RemoteCluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster-a"}}
+	profile := &clusterprofilev1alpha1.ClusterProfile{}
+
+	applyClusterProfileStatus(remoteCluster, profile)
+	if joined := apimeta.FindStatusCondition(profile.Status.Conditions, ConditionJoined); joined == nil || joined.Status != metav1.ConditionFalse {
+		t.Fatalf("expected Joined=False for a RemoteCluster with no UUID yet, got %v", joined)
+	}
+	if healthy := apimeta.FindStatusCondition(profile.Status.Conditions, ConditionControlPlaneHealthy); healthy == nil || healthy.Status != metav1.ConditionFalse {
+		t.Fatalf("expected ControlPlaneHealthy=False for a RemoteCluster that isn't Ready, got %v", healthy)
+	}
+
+	remoteCluster.Status = networkingv1.RemoteClusterStatus{UUID: "uuid-a", Status: networkingv1.ClusterReady}
+	if !applyClusterProfileStatus(remoteCluster, profile) {
+		t.Fatal("expected becoming joined and ready to report a change")
+	}
+	if joined := apimeta.FindStatusCondition(profile.Status.Conditions, ConditionJoined); joined == nil || joined.Status != metav1.ConditionTrue {
+		t.Fatalf("expected Joined=True once the RemoteCluster has a UUID, got %v", joined)
+	}
+	if healthy := apimeta.FindStatusCondition(profile.Status.Conditions, ConditionControlPlaneHealthy); healthy == nil || healthy.Status != metav1.ConditionTrue {
+		t.Fatalf("expected ControlPlaneHealthy=True once the RemoteCluster is Ready, got %v", healthy)
+	}
+}
+
+func TestCredentialProvidersFor(t *testing.T) {
+	if providers := credentialProvidersFor(&networkingv1.RemoteCluster{}); providers != nil {
+		t.Fatalf("expected no CredentialProviders on the spec to yield nil, got %v", providers)
+	}
+
+	remoteCluster := &networkingv1.RemoteCluster{
+		Spec: networkingv1.RemoteClusterSpec{CredentialProviders: []string{"token", "exec"}},
+	}
+	providers := credentialProvidersFor(remoteCluster)
+	if len(providers) != 2 || providers[0].Name != "token" || providers[1].Name != "exec" {
+		t.Fatalf("expected each spec.CredentialProviders entry to become a CredentialProvider by name, got %v", providers)
+	}
+}
@@ -18,6 +18,7 @@ package remotecluster
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"sync"
 	"time"
@@ -42,6 +43,7 @@ import (
 	"github.com/alibaba/hybridnet/pkg/client/informers/externalversions"
 	informers "github.com/alibaba/hybridnet/pkg/client/informers/externalversions/networking/v1"
 	listers "github.com/alibaba/hybridnet/pkg/client/listers/networking/v1"
+	"github.com/alibaba/hybridnet/pkg/controller/remotecluster/clustercache"
 	"github.com/alibaba/hybridnet/pkg/controller/remotecluster/lock"
 	rctypes "github.com/alibaba/hybridnet/pkg/controller/remotecluster/types"
 	"github.com/alibaba/hybridnet/pkg/rcmanager"
@@ -50,10 +52,6 @@ import (
 
 const (
 	ControllerName = "remotecluster"
-
-	// HealthCheckPeriod Every HealthCheckPeriod will resync remote cluster cache and check rc
-	// health. Default: 30 second. Set to zero will also use the default value
-	HealthCheckPeriod = 30 * time.Second
 )
 
 type Controller struct {
@@ -65,7 +63,16 @@ type Controller struct {
 	OverlayNetID   *uint32
 	overlayNetIDMU sync.RWMutex
 
-	rcManagerCache sync.Map
+	// clusterCache owns one lazily-connected, health-tracked Accessor per
+	// joined RemoteCluster. It replaces the bare sync.Map of
+	// *rcmanager.Manager this controller used to keep, and is what sibling
+	// subsystems (remote subnet/vtep reconcilers) should go through instead
+	// of reaching into rcmanager.Manager directly.
+	clusterCache clustercache.ClusterCache
+
+	// remoteHealth caches CountHealthyRemoteClusters so repeated calls
+	// don't re-read every peer's lease on each call.
+	remoteHealth *CachedRemoteHealth
 
 	kubeClient                kubeclientset.Interface
 	hybridnetClient           versioned.Interface
@@ -84,7 +91,26 @@ type Controller struct {
 
 	remoteClusterUUIDLock lock.UUIDLock
 
+	// eventMu guards eventsClosed, which emitEvent checks before sending so
+	// Shutdown can stop new events from being accepted. remoteClusterEvent
+	// itself is never closed: a producer unaware of shutdown could still be
+	// sending on it, and closing a channel a live sender writes to panics.
+	eventMu            sync.RWMutex
+	eventsClosed       bool
 	remoteClusterEvent chan rctypes.Event
+	// eventStopCh is closed by Shutdown to tell handleEventFromRemoteClusters
+	// to drain whatever is already buffered on remoteClusterEvent and return,
+	// instead of ranging forever over a channel that is never closed.
+	eventStopCh chan struct{}
+	// eventDrained is closed once handleEventFromRemoteClusters has drained
+	// remoteClusterEvent and returned.
+	eventDrained chan struct{}
+
+	// healthWG is held for the duration of each renewRemoteLeases/
+	// checkRemoteLeaseExpiry pass, so Shutdown can wait for the in-flight
+	// pass to finish instead of interrupting it mid-write.
+	healthWG     sync.WaitGroup
+	shutdownOnce sync.Once
 
 	recorder record.EventRecorder
 }
@@ -113,7 +139,6 @@ func NewController(
 	c := &Controller{
 		Mutex:                     sync.Mutex{},
 		hasSynced:                 false,
-		rcManagerCache:            sync.Map{},
 		UUID:                      uuid,
 		kubeClient:                kubeClient,
 		hybridnetClient:           hybridnetClient,
@@ -130,14 +155,29 @@ func NewController(
 		remoteClusterQueue:        workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName),
 		remoteClusterUUIDLock:     lock.NewUUIDLock(),
 		remoteClusterEvent:        make(chan rctypes.Event, 10),
+		eventStopCh:               make(chan struct{}),
+		eventDrained:              make(chan struct{}),
 		recorder:                  recorder,
 	}
 
+	c.clusterCache = clustercache.New(clustercache.Options{
+		OnConditionChange: func(clusterName string, _ clustercache.Condition) {
+			c.remoteClusterQueue.Add(clusterName)
+		},
+	})
+	c.remoteHealth = NewCachedRemoteHealth(DefaultRemoteHealthCacheTTL, c.countHealthyRemoteClustersNow)
+
 	remoteClusterInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
 		FilterFunc: c.filterRemoteCluster,
 		Handler: cache.ResourceEventHandlerFuncs{
-			AddFunc:    c.addOrDelRemoteCluster,
-			UpdateFunc: c.updateRemoteCluster,
+			AddFunc: func(obj interface{}) {
+				c.ensureRemoteClusterAccessor(obj)
+				c.addOrDelRemoteCluster(obj)
+			},
+			UpdateFunc: func(oldObj, newObj interface{}) {
+				c.ensureRemoteClusterAccessor(newObj)
+				c.updateRemoteCluster(oldObj, newObj)
+			},
 			DeleteFunc: c.addOrDelRemoteCluster,
 		},
 	})
@@ -203,24 +243,16 @@ func (c *Controller) Run(stopCh <-chan struct{}) error {
 	// start workers
 	klog.Info("Starting workers")
 	go wait.Until(c.runRemoteClusterWorker, time.Second, stopCh)
-	go wait.Until(c.updateAllRemoteClusterStatus, HealthCheckPeriod, stopCh)
-	go wait.Until(c.handleEventFromRemoteClusters, time.Second, stopCh)
+	go wait.Until(c.renewRemoteLeases, DefaultLeaseRenewInterval, stopCh)
+	go wait.Until(c.checkRemoteLeaseExpiry, DefaultLeaseRenewInterval, stopCh)
+	go c.handleEventFromRemoteClusters()
 
 	<-stopCh
 
-	c.closeAllRemoteClusterManager()
-
 	klog.Info("Shutting down workers")
-	return nil
-}
-
-func (c *Controller) closeAllRemoteClusterManager() {
-	c.rcManagerCache.Range(func(_, value interface{}) bool {
-		if manager, ok := value.(*rcmanager.Manager); ok {
-			manager.Close()
-		}
-		return true
-	})
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), DefaultDrainTimeout)
+	defer cancel()
+	return c.Shutdown(shutdownCtx)
 }
 
 func (c *Controller) syncLocalOverlayNetIDOnce() {
@@ -253,102 +285,165 @@ func (c *Controller) syncLocalOverlayNetIDOnce() {
 	}
 }
 
-// health checking and resync cache. remote cluster is managed by admin, it can be
-// treated as desired states
-func (c *Controller) updateAllRemoteClusterStatus() {
-	remoteClusters, err := c.remoteClusterLister.List(labels.Everything())
-	if err != nil {
-		klog.Errorf("Can't list remote cluster. err=%v", err)
-		return
+// handleEventFromRemoteClusters dispatches events until Shutdown closes
+// eventStopCh, at which point it drains whatever is already buffered on
+// remoteClusterEvent and returns. remoteClusterEvent itself is never closed,
+// so it stays select'd here rather than ranged over.
+//
+// Run starts this as a single plain goroutine rather than through
+// wait.Until: unlike the periodic workers above, it is already its own
+// long-lived loop, and Shutdown is documented to be callable directly while
+// stopCh is still open (e.g. from a SIGTERM handler). wait.Until would see
+// stopCh still open and restart this function roughly a second after
+// eventStopCh closed it once, closing the already-closed eventDrained again
+// and panicking.
+func (c *Controller) handleEventFromRemoteClusters() {
+	defer close(c.eventDrained)
+
+	for {
+		select {
+		case event := <-c.remoteClusterEvent:
+			if c.dispatchRemoteClusterEvent(event) {
+				time.Sleep(100 * time.Millisecond)
+			}
+		case <-c.eventStopCh:
+			c.drainRemoteClusterEvents()
+			return
+		}
 	}
+}
 
-	var wg sync.WaitGroup
-	for _, rc := range remoteClusters {
-		r := rc.DeepCopy()
+// drainRemoteClusterEvents processes whatever is already buffered on
+// remoteClusterEvent without blocking for more, so Shutdown's drain has a
+// clear end instead of racing a producer that arrives after eventStopCh.
+func (c *Controller) drainRemoteClusterEvents() {
+	for {
+		select {
+		case event := <-c.remoteClusterEvent:
+			c.dispatchRemoteClusterEvent(event)
+		default:
+			return
+		}
+	}
+}
 
-		managerObject, ok := c.rcManagerCache.Load(r.Name)
+// dispatchRemoteClusterEvent handles a single event and reports whether the
+// caller should apply its usual inter-event delay. It returns false on a
+// UUID lock failure: the event is dropped, not retried, and the next event
+// is handled without waiting out the delay.
+func (c *Controller) dispatchRemoteClusterEvent(event rctypes.Event) bool {
+	switch event.Type {
+	case rctypes.EventRefreshUUID:
+		uuid, ok := event.Object.(types.UID)
 		if !ok {
-			continue
+			klog.Warningf("[remote cluster] invalid object of remote cluster event")
+			break
 		}
-		manager, ok := managerObject.(*rcmanager.Manager)
-		if !ok {
-			continue
+		if len(event.ClusterName) == 0 {
+			klog.Warningf("[remote cluster] invalid cluster name for remote cluster event")
+			break
+		}
+		if err := c.remoteClusterUUIDLock.LockByOwner(uuid, event.ClusterName); err != nil {
+			klog.Errorf("[remote cluster] uuid lock failed: %v", err)
+			return false
 		}
 
-		wg.Add(1)
-		go func() {
-			updateSingleRemoteClusterStatus(c, manager, r)
-			wg.Done()
-		}()
-	}
-	wg.Wait()
-}
+		_ = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			return c.patchUUIDtoRemoteCluster(event.ClusterName, uuid)
+		})
+		klog.Infof("[remote cluster] receive event and update UUID %s for cluster %s", uuid, event.ClusterName)
 
-func (c *Controller) handleEventFromRemoteClusters() {
-	for event := range c.remoteClusterEvent {
-		switch event.Type {
-		case rctypes.EventRefreshUUID:
-			uuid, ok := event.Object.(types.UID)
-			if !ok {
-				klog.Warningf("[remote cluster] invalid object of remote cluster event")
-				break
-			}
-			if len(event.ClusterName) == 0 {
-				klog.Warningf("[remote cluster] invalid cluster name for remote cluster event")
-				break
-			}
-			if err := c.remoteClusterUUIDLock.LockByOwner(uuid, event.ClusterName); err != nil {
-				klog.Errorf("[remote cluster] uuid lock failed: %v", err)
-				continue
-			}
+	case rctypes.EventUpdateStatus:
+		if len(event.ClusterName) == 0 {
+			klog.Warningf("invalid cluster for remote cluster event")
+			break
+		}
 
-			_ = retry.RetryOnConflict(retry.DefaultRetry, func() error {
-				return c.patchUUIDtoRemoteCluster(event.ClusterName, uuid)
-			})
-			klog.Infof("[remote cluster] receive event and update UUID %s for cluster %s", uuid, event.ClusterName)
+		remoteCluster, err := c.remoteClusterLister.Get(event.ClusterName)
+		if err != nil {
+			klog.Errorf("update status event fail on getting object: %v", err)
+			break
+		}
+		remoteCluster = remoteCluster.DeepCopy()
 
-		case rctypes.EventUpdateStatus:
-			if len(event.ClusterName) == 0 {
-				klog.Warningf("invalid cluster for remote cluster event")
-				break
-			}
+		accessor, ok := c.clusterCache.GetAccessor(event.ClusterName)
+		if !ok {
+			break
+		}
+		manager, err := accessor.Manager()
+		if err != nil {
+			klog.Warningf("[remote cluster] update status event fail on connecting: %v", err)
+			break
+		}
 
-			remoteCluster, err := c.remoteClusterLister.Get(event.ClusterName)
-			if err != nil {
-				klog.Errorf("update status event fail on getting object: %v", err)
-				break
-			}
-			remoteCluster = remoteCluster.DeepCopy()
+		go updateSingleRemoteClusterStatus(c, manager, remoteCluster)
+		klog.Infof("[remote cluster] receive event and update status for cluster %s", event.ClusterName)
+	case rctypes.EventRecordEvent:
+		if len(event.ClusterName) == 0 {
+			klog.Warningf("invalid cluster for record event event")
+			break
+		}
 
-			managerObject, ok := c.rcManagerCache.Load(event.ClusterName)
-			if !ok {
-				break
-			}
+		eventBody, ok := event.Object.(rctypes.EventBody)
+		if !ok {
+			break
+		}
 
-			go updateSingleRemoteClusterStatus(c, managerObject.(*rcmanager.Manager), remoteCluster)
-			klog.Infof("[remote cluster] receive event and update status for cluster %s", event.ClusterName)
-		case rctypes.EventRecordEvent:
-			if len(event.ClusterName) == 0 {
-				klog.Warningf("invalid cluster for record event event")
-				break
-			}
+		remoteCluster, err := c.remoteClusterLister.Get(event.ClusterName)
+		if err != nil {
+			klog.Errorf("record event fail on getting object: %v", err)
+			break
+		}
 
-			eventBody, ok := event.Object.(rctypes.EventBody)
-			if !ok {
-				break
-			}
+		c.recorder.Event(remoteCluster, eventBody.EventType, eventBody.Reason, eventBody.Message)
+		klog.Infof("[remote cluster] record event %v for cluster %s", eventBody, event.ClusterName)
+	}
+	return true
+}
 
-			remoteCluster, err := c.remoteClusterLister.Get(event.ClusterName)
-			if err != nil {
-				klog.Errorf("record event fail on getting object: %v", err)
-				break
-			}
+// ensureRemoteClusterAccessor makes sure clusterCache holds an Accessor for
+// the RemoteCluster in obj, creating one on first sight and refreshing its
+// config on every subsequent Add/Update so a rotated kubeconfig is actually
+// picked up. This is what actually populates clusterCache now that
+// rcManagerCache is gone: without it, GetAccessor/GetClient would never see
+// anything and every Connect would have to be driven by some other caller
+// reaching for GetOrCreateAccessor directly, which none of the sibling
+// reconcilers do.
+func (c *Controller) ensureRemoteClusterAccessor(obj interface{}) {
+	remoteCluster, ok := obj.(*networkingv1.RemoteCluster)
+	if !ok {
+		return
+	}
 
-			c.recorder.Event(remoteCluster, eventBody.EventType, eventBody.Reason, eventBody.Message)
-			klog.Infof("[remote cluster] record event %v for cluster %s", eventBody, event.ClusterName)
-		}
-		time.Sleep(100 * time.Millisecond)
+	config, err := connectionConfigFor(remoteCluster)
+	if err != nil {
+		klog.Warningf("[remote cluster] failed to build connection config for %s: %v", remoteCluster.Name, err)
+		return
+	}
+
+	if _, err := c.clusterCache.GetOrCreateAccessor(remoteCluster.Name, config); err != nil {
+		klog.Warningf("[remote cluster] failed to create cluster cache accessor for %s: %v", remoteCluster.Name, err)
+	}
+}
+
+// connectionConfigFor builds the rcmanager.Config used to dial remoteCluster.
+// It round-trips remoteCluster.Spec through JSON into a Config, the same
+// tag-driven conversion the propagation package uses for Subnet/Vtep specs,
+// so this carries over whatever connection fields (endpoint, credentials,
+// ...) the two types agree on by json tag without this package having to
+// hard-code RemoteClusterSpec's field names.
+func connectionConfigFor(remoteCluster *networkingv1.RemoteCluster) (*rcmanager.Config, error) {
+	data, err := json.Marshal(remoteCluster.Spec)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &rcmanager.Config{}
+	if err := json.Unmarshal(data, config); err != nil {
+		return nil, err
 	}
+	config.ClusterName = remoteCluster.Name
+	return config, nil
 }
 
 func (c *Controller) patchUUIDtoRemoteCluster(clusterName string, uuid types.UID) error {
@@ -0,0 +1,246 @@
+/*
+ Copyright 2021 The Hybridnet Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package remotecluster
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog"
+)
+
+const (
+	// LeaseNamespace is where each hybridnet controller instance writes its
+	// liveness lease, both into every joined remote cluster and, from each
+	// peer's perspective, into the local one.
+	LeaseNamespace = "kube-system"
+
+	// DefaultLeaseRenewInterval is how often a controller instance renews
+	// its outbound lease in each joined remote cluster.
+	DefaultLeaseRenewInterval = 10 * time.Second
+
+	// DefaultLeaseDuration is how long a lease is considered current after
+	// its last renewal, mirroring apiserver-network-proxy's server-count
+	// lease duration.
+	DefaultLeaseDuration = 30 * time.Second
+
+	// DefaultRemoteHealthCacheTTL bounds how often CountHealthyRemoteClusters
+	// re-reads leases rather than returning its cached count.
+	DefaultRemoteHealthCacheTTL = 15 * time.Second
+
+	// RemoteClusterLeaseLabel marks a lease as belonging to hybridnet's
+	// cross-cluster liveness tracking, so CountHealthyRemoteClusters can
+	// filter out unrelated leases sharing the namespace.
+	RemoteClusterLeaseLabel = "hybridnet.io/remote-cluster-liveness"
+)
+
+// CachedRemoteHealth memoizes a peer-liveness count behind a short
+// expiration, so repeated CountHealthyRemoteClusters calls don't hit the
+// local apiserver's Lease API on every call.
+type CachedRemoteHealth struct {
+	mu        sync.Mutex
+	ttl       time.Duration
+	refresh   func() (int, error)
+	value     int
+	expiresAt time.Time
+}
+
+// NewCachedRemoteHealth returns a CachedRemoteHealth that calls refresh at
+// most once per ttl.
+func NewCachedRemoteHealth(ttl time.Duration, refresh func() (int, error)) *CachedRemoteHealth {
+	return &CachedRemoteHealth{ttl: ttl, refresh: refresh}
+}
+
+// Get returns the cached count, recomputing it via refresh if it has expired.
+func (h *CachedRemoteHealth) Get() (int, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if time.Now().Before(h.expiresAt) {
+		return h.value, nil
+	}
+
+	value, err := h.refresh()
+	if err != nil {
+		return h.value, err
+	}
+
+	h.value = value
+	h.expiresAt = time.Now().Add(h.ttl)
+	return h.value, nil
+}
+
+// CountHealthyRemoteClusters reports how many joined RemoteClusters have a
+// current (non-expired) liveness lease in the local cluster, as renewed by
+// the peer's own controller instance. This replaces round-tripping an API
+// call to every remote cluster just to learn it is still alive.
+func (c *Controller) CountHealthyRemoteClusters() (int, error) {
+	return c.remoteHealth.Get()
+}
+
+func (c *Controller) countHealthyRemoteClustersNow() (int, error) {
+	remoteClusters, err := c.remoteClusterLister.List(labels.Everything())
+	if err != nil {
+		return 0, err
+	}
+
+	var healthy int
+	now := time.Now()
+	for _, rc := range remoteClusters {
+		if len(rc.Status.UUID) == 0 {
+			continue
+		}
+
+		lease, err := c.kubeClient.CoordinationV1().Leases(LeaseNamespace).Get(context.TODO(), string(rc.Status.UUID), metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			klog.Warningf("[remote cluster] failed to get liveness lease for %s: %v", rc.Name, err)
+			continue
+		}
+		if lease.Labels[RemoteClusterLeaseLabel] != "true" {
+			continue
+		}
+
+		if leaseCurrent(lease, now) {
+			healthy++
+		}
+	}
+	return healthy, nil
+}
+
+func leaseCurrent(lease *coordinationv1.Lease, now time.Time) bool {
+	if lease.Spec.RenewTime == nil || lease.Spec.LeaseDurationSeconds == nil {
+		return false
+	}
+	expiry := lease.Spec.RenewTime.Add(time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second)
+	return now.Before(expiry)
+}
+
+// renewRemoteLeases renews this controller instance's outbound liveness
+// lease in every connected remote cluster, once per
+// DefaultLeaseRenewInterval (wired in Run via wait.Until).
+func (c *Controller) renewRemoteLeases() {
+	c.healthWG.Add(1)
+	defer c.healthWG.Done()
+
+	remoteClusters, err := c.remoteClusterLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("[remote cluster] can't list remote clusters for lease renewal: %v", err)
+		return
+	}
+
+	for _, rc := range remoteClusters {
+		r := rc
+		accessor, ok := c.clusterCache.GetAccessor(r.Name)
+		if !ok {
+			continue
+		}
+
+		kubeClient, _, err := accessor.Connect()
+		if err != nil {
+			continue
+		}
+
+		if err := renewLease(kubeClient.CoordinationV1().Leases(LeaseNamespace), string(c.UUID)); err != nil {
+			klog.Warningf("[remote cluster] failed to renew liveness lease in %s: %v", r.Name, err)
+			continue
+		}
+	}
+}
+
+type leaseInterface interface {
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*coordinationv1.Lease, error)
+	Create(ctx context.Context, lease *coordinationv1.Lease, opts metav1.CreateOptions) (*coordinationv1.Lease, error)
+	Update(ctx context.Context, lease *coordinationv1.Lease, opts metav1.UpdateOptions) (*coordinationv1.Lease, error)
+}
+
+func renewLease(leases leaseInterface, name string) error {
+	now := metav1.NowMicro()
+	durationSeconds := int32(DefaultLeaseDuration / time.Second)
+
+	existing, err := leases.Get(context.TODO(), name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		_, err = leases.Create(context.TODO(), &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: LeaseNamespace,
+				Labels:    map[string]string{RemoteClusterLeaseLabel: "true"},
+			},
+			Spec: coordinationv1.LeaseSpec{
+				RenewTime:            &now,
+				LeaseDurationSeconds: &durationSeconds,
+			},
+		}, metav1.CreateOptions{})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	existing = existing.DeepCopy()
+	existing.Spec.RenewTime = &now
+	existing.Spec.LeaseDurationSeconds = &durationSeconds
+	_, err = leases.Update(context.TODO(), existing, metav1.UpdateOptions{})
+	return err
+}
+
+// checkRemoteLeaseExpiry looks for remote clusters whose inbound lease has
+// expired and, on every pass, disconnects the associated accessor directly, marking its
+// Condition unhealthy so the existing OnConditionChange -> remoteClusterQueue
+// pipeline re-enqueues and downgrades the RemoteCluster's status. It does
+// not go through emitEvent/EventUpdateStatus: that handler's job is to
+// reconnect and push a fresh status, which is the opposite of what an
+// expired lease calls for.
+func (c *Controller) checkRemoteLeaseExpiry() {
+	c.healthWG.Add(1)
+	defer c.healthWG.Done()
+
+	remoteClusters, err := c.remoteClusterLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("[remote cluster] can't list remote clusters for lease expiry check: %v", err)
+		return
+	}
+
+	now := time.Now()
+	for _, rc := range remoteClusters {
+		if len(rc.Status.UUID) == 0 {
+			continue
+		}
+
+		lease, err := c.kubeClient.CoordinationV1().Leases(LeaseNamespace).Get(context.TODO(), string(rc.Status.UUID), metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			continue
+		}
+
+		if !leaseCurrent(lease, now) {
+			if accessor, ok := c.clusterCache.GetAccessor(rc.Name); ok {
+				klog.Warningf("[remote cluster] lease for %s expired, closing its accessor", rc.Name)
+				accessor.DisconnectWithReason("remote liveness lease expired")
+			}
+		}
+	}
+}
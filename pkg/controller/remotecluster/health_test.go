@@ -0,0 +1,101 @@
+/*
+ Copyright 2021 The Hybridnet Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package remotecluster
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestLeaseCurrent(t *testing.T) {
+	now := time.Unix(1000, 0)
+	durationSeconds := int32(30)
+	renewTime := metav1.NewMicroTime(now.Add(-10 * time.Second))
+
+	current := &coordinationv1.Lease{
+		Spec: coordinationv1.LeaseSpec{
+			RenewTime:            &renewTime,
+			LeaseDurationSeconds: &durationSeconds,
+		},
+	}
+	if !leaseCurrent(current, now) {
+		t.Fatal("expected a lease renewed 10s ago with a 30s duration to still be current")
+	}
+
+	expiredRenewTime := metav1.NewMicroTime(now.Add(-40 * time.Second))
+	expired := &coordinationv1.Lease{
+		Spec: coordinationv1.LeaseSpec{
+			RenewTime:            &expiredRenewTime,
+			LeaseDurationSeconds: &durationSeconds,
+		},
+	}
+	if leaseCurrent(expired, now) {
+		t.Fatal("expected a lease renewed 40s ago with a 30s duration to be expired")
+	}
+
+	if leaseCurrent(&coordinationv1.Lease{}, now) {
+		t.Fatal("expected a lease with no RenewTime/LeaseDurationSeconds to never be current")
+	}
+}
+
+func TestCachedRemoteHealthGet(t *testing.T) {
+	var calls int
+	refresh := func() (int, error) {
+		calls++
+		return calls, nil
+	}
+	h := NewCachedRemoteHealth(50*time.Millisecond, refresh)
+
+	value, err := h.Get()
+	if err != nil || value != 1 {
+		t.Fatalf("expected first Get to refresh and return 1, got %d, %v", value, err)
+	}
+
+	value, err = h.Get()
+	if err != nil || value != 1 {
+		t.Fatalf("expected a second immediate Get to return the cached value, got %d, %v", value, err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected only one refresh within the ttl, got %d", calls)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	value, err = h.Get()
+	if err != nil || value != 2 {
+		t.Fatalf("expected Get past the ttl to refresh again, got %d, %v", value, err)
+	}
+}
+
+func TestCachedRemoteHealthGetReturnsStaleValueOnRefreshError(t *testing.T) {
+	refresh := func() (int, error) {
+		return 0, errors.New("boom")
+	}
+	h := NewCachedRemoteHealth(time.Millisecond, refresh)
+	h.value = 7
+
+	value, err := h.Get()
+	if err == nil {
+		t.Fatal("expected Get to surface the refresh error")
+	}
+	if value != 7 {
+		t.Fatalf("expected Get to return the last known value on refresh error, got %d", value)
+	}
+}
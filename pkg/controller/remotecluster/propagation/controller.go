@@ -0,0 +1,706 @@
+/*
+ Copyright 2021 The Hybridnet Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+// Package propagation drives which local Subnet/Network/Vtep objects are
+// exported as RemoteSubnet/RemoteVtep objects into which joined
+// RemoteClusters, as decided by RemoteClusterPropagationPolicy and
+// ClusterRemoteClusterPropagationPolicy objects. Before this package
+// existed, the remotecluster controller fanned every local resource out to
+// every joined cluster unconditionally.
+package propagation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog"
+
+	networkingv1 "github.com/alibaba/hybridnet/pkg/apis/networking/v1"
+	"github.com/alibaba/hybridnet/pkg/client/clientset/versioned"
+	informers "github.com/alibaba/hybridnet/pkg/client/informers/externalversions/networking/v1"
+	listers "github.com/alibaba/hybridnet/pkg/client/listers/networking/v1"
+	"github.com/alibaba/hybridnet/pkg/controller/remotecluster/clustercache"
+)
+
+const ControllerName = "propagation"
+
+const (
+	// PropagationPolicyLabel records the name of the RemoteClusterPropagationPolicy
+	// or ClusterRemoteClusterPropagationPolicy that propagated a RemoteSubnet/
+	// RemoteVtep into a target cluster. reconcileLastApplied reads it back on
+	// startup to rebuild lastApplied from what is actually live in each
+	// target cluster, instead of starting from an empty map and leaking
+	// anything that went stale while the controller was down.
+	PropagationPolicyLabel = "propagation.hybridnet.io/owner-policy"
+	// PropagationPolicyNamespaceLabel is set alongside PropagationPolicyLabel
+	// for a namespace-scoped RemoteClusterPropagationPolicy owner; it is
+	// omitted for the cluster-scoped ClusterRemoteClusterPropagationPolicy.
+	PropagationPolicyNamespaceLabel = "propagation.hybridnet.io/owner-policy-namespace"
+	// PropagationSourceNamespaceLabel records the source object's own
+	// namespace (set for namespaced kinds like Vtep) so tupleKeyOf can match
+	// a reconciled AppliedResource against one computed during a live sync.
+	PropagationSourceNamespaceLabel = "propagation.hybridnet.io/source-namespace"
+)
+
+// policyKey identifies a namespace-scoped or cluster-scoped policy; Namespace
+// is empty for the cluster-scoped kind.
+type policyKey struct {
+	namespace string
+	name      string
+}
+
+// Controller computes, for each (local resource, remote cluster) pair,
+// whether propagation applies, and drives create/delete of RemoteSubnet/
+// RemoteVtep objects in the target cluster accordingly.
+type Controller struct {
+	hybridnetClient versioned.Interface
+	clusterCache    clustercache.ClusterCache
+
+	policyLister        listers.RemoteClusterPropagationPolicyLister
+	clusterPolicyLister listers.ClusterRemoteClusterPropagationPolicyLister
+	subnetLister        listers.SubnetLister
+	networkLister       listers.NetworkLister
+	vtepLister          listers.VtepLister
+	remoteClusterLister listers.RemoteClusterLister
+	// namespaceLister resolves a namespace's own labels so ResourceSelector.
+	// NamespaceSelector can be matched against namespace-scoped kinds (Vtep);
+	// Subnet and Network are cluster-scoped and never consult it.
+	namespaceLister corelisters.NamespaceLister
+
+	synced []cache.InformerSynced
+	queue  workqueue.RateLimitingInterface
+
+	// lastAppliedMu guards lastApplied, which remembers the most recent set
+	// of (resource, cluster) tuples each policy propagated, so a resource or
+	// cluster that stops matching - or a policy that is deleted outright -
+	// can have its previously-created RemoteSubnet/RemoteVtep objects
+	// cleaned up instead of leaking in the target cluster forever. It starts
+	// empty on every restart; reconcileLastApplied repopulates it from the
+	// PropagationPolicyLabel already stamped on live objects in each target
+	// cluster before workers start, so a restart mid-flight does not forget
+	// about tuples that need cleaning up.
+	lastAppliedMu sync.Mutex
+	lastApplied   map[policyKey][]networkingv1.AppliedResource
+}
+
+func NewController(
+	hybridnetClient versioned.Interface,
+	clusterCache clustercache.ClusterCache,
+	policyInformer informers.RemoteClusterPropagationPolicyInformer,
+	clusterPolicyInformer informers.ClusterRemoteClusterPropagationPolicyInformer,
+	subnetInformer informers.SubnetInformer,
+	networkInformer informers.NetworkInformer,
+	vtepInformer informers.VtepInformer,
+	remoteClusterInformer informers.RemoteClusterInformer,
+	namespaceInformer coreinformers.NamespaceInformer,
+) *Controller {
+	c := &Controller{
+		hybridnetClient:     hybridnetClient,
+		clusterCache:        clusterCache,
+		policyLister:        policyInformer.Lister(),
+		clusterPolicyLister: clusterPolicyInformer.Lister(),
+		subnetLister:        subnetInformer.Lister(),
+		networkLister:       networkInformer.Lister(),
+		vtepLister:          vtepInformer.Lister(),
+		remoteClusterLister: remoteClusterInformer.Lister(),
+		namespaceLister:     namespaceInformer.Lister(),
+		lastApplied:         make(map[policyKey][]networkingv1.AppliedResource),
+		synced: []cache.InformerSynced{
+			policyInformer.Informer().HasSynced,
+			clusterPolicyInformer.Informer().HasSynced,
+			subnetInformer.Informer().HasSynced,
+			networkInformer.Informer().HasSynced,
+			vtepInformer.Informer().HasSynced,
+			remoteClusterInformer.Informer().HasSynced,
+			namespaceInformer.Informer().HasSynced,
+		},
+		queue: workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName),
+	}
+
+	policyInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { c.enqueuePolicy(obj, false) },
+		UpdateFunc: func(_, newObj interface{}) { c.enqueuePolicy(newObj, false) },
+		DeleteFunc: func(obj interface{}) { c.enqueuePolicy(obj, false) },
+	})
+	clusterPolicyInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { c.enqueuePolicy(obj, true) },
+		UpdateFunc: func(_, newObj interface{}) { c.enqueuePolicy(newObj, true) },
+		DeleteFunc: func(obj interface{}) { c.enqueuePolicy(obj, true) },
+	})
+
+	resourceHandler := cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(interface{}) { c.enqueueAllPolicies() },
+		UpdateFunc: func(interface{}, interface{}) { c.enqueueAllPolicies() },
+		DeleteFunc: func(interface{}) { c.enqueueAllPolicies() },
+	}
+	subnetInformer.Informer().AddEventHandler(resourceHandler)
+	networkInformer.Informer().AddEventHandler(resourceHandler)
+	vtepInformer.Informer().AddEventHandler(resourceHandler)
+	remoteClusterInformer.Informer().AddEventHandler(resourceHandler)
+	namespaceInformer.Informer().AddEventHandler(resourceHandler)
+
+	return c
+}
+
+func (c *Controller) enqueuePolicy(obj interface{}, clusterScoped bool) {
+	if clusterScoped {
+		if p, ok := obj.(*networkingv1.ClusterRemoteClusterPropagationPolicy); ok {
+			c.queue.Add(policyKey{name: p.Name})
+		}
+		return
+	}
+	if p, ok := obj.(*networkingv1.RemoteClusterPropagationPolicy); ok {
+		c.queue.Add(policyKey{namespace: p.Namespace, name: p.Name})
+	}
+}
+
+func (c *Controller) enqueueAllPolicies() {
+	policies, err := c.policyLister.List(labels.Everything())
+	if err == nil {
+		for _, p := range policies {
+			c.queue.Add(policyKey{namespace: p.Namespace, name: p.Name})
+		}
+	}
+	clusterPolicies, err := c.clusterPolicyLister.List(labels.Everything())
+	if err == nil {
+		for _, p := range clusterPolicies {
+			c.queue.Add(policyKey{name: p.Name})
+		}
+	}
+}
+
+func (c *Controller) Run(workers int, stopCh <-chan struct{}) error {
+	defer utilruntime.HandleCrash()
+	defer c.queue.ShutDown()
+
+	klog.Infof("Starting %s controller", ControllerName)
+	if ok := cache.WaitForCacheSync(stopCh, c.synced...); !ok {
+		return fmt.Errorf("%s failed to wait for caches to sync", ControllerName)
+	}
+
+	c.reconcileLastApplied()
+
+	for i := 0; i < workers; i++ {
+		go wait.Until(c.runWorker, time.Second, stopCh)
+	}
+
+	<-stopCh
+	klog.Info("Shutting down propagation workers")
+	return nil
+}
+
+func (c *Controller) runWorker() {
+	for c.processNextWorkItem() {
+	}
+}
+
+func (c *Controller) processNextWorkItem() bool {
+	key, shutdown := c.queue.Get()
+	if shutdown {
+		return false
+	}
+	defer c.queue.Done(key)
+
+	pk := key.(policyKey)
+	if err := c.sync(pk); err != nil {
+		c.queue.AddRateLimited(key)
+		utilruntime.HandleError(fmt.Errorf("[propagation] sync %+v failed: %w", pk, err))
+		return true
+	}
+
+	c.queue.Forget(key)
+	return true
+}
+
+// sync computes the full set of (resource, cluster) tuples the policy named
+// by pk currently applies to, drives RemoteSubnet/RemoteVtep create/delete
+// for each - including deleting tuples that were applied on a previous sync
+// but no longer match - and records the result in the policy's status.
+func (c *Controller) sync(pk policyKey) error {
+	spec, statusWriter, err := c.getPolicy(pk)
+	if apierrors.IsNotFound(err) {
+		// The policy itself is gone: everything it last applied must be
+		// torn down, there is no status left to write it to.
+		c.cleanupPolicy(pk)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	remoteClusters, err := c.remoteClusterLister.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+
+	var applied []networkingv1.AppliedResource
+	for _, selector := range spec.ResourceSelectors {
+		resources, err := c.matchResources(selector)
+		if err != nil {
+			return err
+		}
+
+		for _, resource := range resources {
+			for _, target := range matchingClusters(spec.Placement, remoteClusters) {
+				ok, reason := c.propagate(selector.Kind, resource, target, pk)
+				applied = append(applied, networkingv1.AppliedResource{
+					Kind:         selector.Kind,
+					Name:         resource.Name,
+					Namespace:    resource.Namespace,
+					ClusterName:  target.Name,
+					Applied:      ok,
+					Reason:       reason,
+					LastSyncTime: metav1.Now(),
+				})
+			}
+		}
+	}
+
+	c.removeStaleResources(pk, applied)
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		return statusWriter(applied)
+	})
+}
+
+// tupleKey identifies a single (kind, name, namespace, cluster) tuple, the
+// granularity at which propagation is applied and cleaned up.
+type tupleKey struct {
+	kind        networkingv1.ResourceKind
+	name        string
+	namespace   string
+	clusterName string
+}
+
+func tupleKeyOf(ar networkingv1.AppliedResource) tupleKey {
+	return tupleKey{kind: ar.Kind, name: ar.Name, namespace: ar.Namespace, clusterName: ar.ClusterName}
+}
+
+// removeStaleResources diffs applied (this sync's result) against the
+// previous sync's recorded tuples for pk, deleting the RemoteSubnet/
+// RemoteVtep for any tuple that is no longer present - e.g. the source
+// Subnet/Vtep was deleted or relabeled out of the selector, or the target
+// cluster dropped out of the Placement - and remembers applied for next
+// time.
+func (c *Controller) removeStaleResources(pk policyKey, applied []networkingv1.AppliedResource) {
+	current := make(map[tupleKey]struct{}, len(applied))
+	for _, ar := range applied {
+		if ar.Applied {
+			current[tupleKeyOf(ar)] = struct{}{}
+		}
+	}
+
+	c.lastAppliedMu.Lock()
+	previous := c.lastApplied[pk]
+	c.lastApplied[pk] = applied
+	c.lastAppliedMu.Unlock()
+
+	for _, ar := range previous {
+		if !ar.Applied {
+			continue
+		}
+		if _, stillApplied := current[tupleKeyOf(ar)]; !stillApplied {
+			c.deletePropagated(ar)
+		}
+	}
+}
+
+// cleanupPolicy tears down every tuple pk last applied, used when the policy
+// object itself has been deleted.
+func (c *Controller) cleanupPolicy(pk policyKey) {
+	c.lastAppliedMu.Lock()
+	previous := c.lastApplied[pk]
+	delete(c.lastApplied, pk)
+	c.lastAppliedMu.Unlock()
+
+	for _, ar := range previous {
+		if ar.Applied {
+			c.deletePropagated(ar)
+		}
+	}
+}
+
+// reconcileLastApplied rebuilds lastApplied from whatever RemoteSubnet/
+// RemoteVtep objects already carry a PropagationPolicyLabel in each joined
+// cluster, so a controller that restarts mid-flight has something real to
+// diff the next sync's result against instead of an empty map - which would
+// otherwise make removeStaleResources treat every previously-propagated
+// object as having nothing to clean up against, leaking it forever if its
+// source stopped matching while the controller was down. It runs once, before
+// workers start. A cluster whose client can't be obtained then is skipped:
+// its objects are tracked again only when a later sync propagates them, and
+// ones whose source stopped matching in the meantime are not cleaned up.
+func (c *Controller) reconcileLastApplied() {
+	remoteClusters, err := c.remoteClusterLister.List(labels.Everything())
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("[propagation] can't list remote clusters to reconcile lastApplied: %w", err))
+		return
+	}
+
+	rebuilt := make(map[policyKey][]networkingv1.AppliedResource)
+	for _, rc := range remoteClusters {
+		_, remoteHybridnetClient, err := c.clusterCache.GetClient(rc.Name)
+		if err != nil {
+			continue
+		}
+
+		if subnets, err := remoteHybridnetClient.NetworkingV1().RemoteSubnets().List(context.TODO(), metav1.ListOptions{}); err == nil {
+			for i := range subnets.Items {
+				rememberReconciled(rebuilt, rc.Name, networkingv1.ResourceKindSubnet, subnets.Items[i].Name, subnets.Items[i].Labels)
+			}
+		}
+		if vteps, err := remoteHybridnetClient.NetworkingV1().RemoteVteps().List(context.TODO(), metav1.ListOptions{}); err == nil {
+			for i := range vteps.Items {
+				rememberReconciled(rebuilt, rc.Name, networkingv1.ResourceKindVtep, vteps.Items[i].Name, vteps.Items[i].Labels)
+			}
+		}
+	}
+
+	c.lastAppliedMu.Lock()
+	for pk, applied := range rebuilt {
+		c.lastApplied[pk] = applied
+	}
+	c.lastAppliedMu.Unlock()
+}
+
+// rememberReconciled records name/kind as applied by the policy identified
+// by objLabels, skipping anything that was not created by this controller
+// (no PropagationPolicyLabel) so objects belonging to something else are
+// never treated as ours to clean up.
+func rememberReconciled(rebuilt map[policyKey][]networkingv1.AppliedResource, clusterName string, kind networkingv1.ResourceKind, name string, objLabels map[string]string) {
+	policyName, ok := objLabels[PropagationPolicyLabel]
+	if !ok {
+		return
+	}
+
+	pk := policyKey{name: policyName, namespace: objLabels[PropagationPolicyNamespaceLabel]}
+	rebuilt[pk] = append(rebuilt[pk], networkingv1.AppliedResource{
+		Kind:        kind,
+		Name:        name,
+		Namespace:   objLabels[PropagationSourceNamespaceLabel],
+		ClusterName: clusterName,
+		Applied:     true,
+	})
+}
+
+// deletePropagated removes the RemoteSubnet/RemoteVtep mirroring ar from its
+// target cluster.
+func (c *Controller) deletePropagated(ar networkingv1.AppliedResource) {
+	_, remoteHybridnetClient, err := c.clusterCache.GetClient(ar.ClusterName)
+	if err != nil {
+		klog.Warningf("[propagation] can't clean up %s %s in %s: %v", ar.Kind, ar.Name, ar.ClusterName, err)
+		return
+	}
+
+	var deleteErr error
+	switch ar.Kind {
+	case networkingv1.ResourceKindSubnet:
+		deleteErr = remoteHybridnetClient.NetworkingV1().RemoteSubnets().Delete(context.TODO(), ar.Name, metav1.DeleteOptions{})
+	case networkingv1.ResourceKindVtep:
+		deleteErr = remoteHybridnetClient.NetworkingV1().RemoteVteps().Delete(context.TODO(), ar.Name, metav1.DeleteOptions{})
+	default:
+		return
+	}
+
+	if deleteErr != nil && !apierrors.IsNotFound(deleteErr) {
+		klog.Warningf("[propagation] failed to delete %s %s from %s: %v", ar.Kind, ar.Name, ar.ClusterName, deleteErr)
+	}
+}
+
+// matchedResource carries the source object's own Spec alongside its
+// identity, so propagate can mirror the real network data (CIDR, gateway,
+// VTEP address, ...) onto the RemoteSubnet/RemoteVtep it creates or updates,
+// instead of an empty shell that only has a name and labels.
+type matchedResource struct {
+	Name      string
+	Namespace string
+	Labels    map[string]string
+	Spec      interface{}
+}
+
+func (c *Controller) matchResources(selector networkingv1.ResourceSelector) ([]matchedResource, error) {
+	resourceSelector := labels.Everything()
+	if selector.LabelSelector != nil {
+		var err error
+		resourceSelector, err = metav1.LabelSelectorAsSelector(selector.LabelSelector)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var out []matchedResource
+	switch selector.Kind {
+	case networkingv1.ResourceKindSubnet:
+		subnets, err := c.subnetLister.List(resourceSelector)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range subnets {
+			out = append(out, matchedResource{Name: s.Name, Labels: s.Labels, Spec: s.Spec})
+		}
+	case networkingv1.ResourceKindNetwork:
+		networks, err := c.networkLister.List(resourceSelector)
+		if err != nil {
+			return nil, err
+		}
+		for _, n := range networks {
+			out = append(out, matchedResource{Name: n.Name, Labels: n.Labels, Spec: n.Spec})
+		}
+	case networkingv1.ResourceKindVtep:
+		vteps, err := c.vtepLister.List(resourceSelector)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range vteps {
+			matches, err := c.namespaceMatches(selector.NamespaceSelector, v.Namespace)
+			if err != nil {
+				return nil, err
+			}
+			if !matches {
+				continue
+			}
+			out = append(out, matchedResource{Name: v.Name, Namespace: v.Namespace, Labels: v.Labels, Spec: v.Spec})
+		}
+	default:
+		return nil, fmt.Errorf("propagation: unsupported resource kind %q", selector.Kind)
+	}
+	return out, nil
+}
+
+// namespaceMatches reports whether namespace's own labels satisfy selector.
+// A nil selector always matches. Only Vtep selectors go through this;
+// matchResources never calls it for the cluster-scoped Subnet/Network kinds.
+func (c *Controller) namespaceMatches(selector *metav1.LabelSelector, namespace string) (bool, error) {
+	if selector == nil {
+		return true, nil
+	}
+
+	ns, err := c.namespaceLister.Get(namespace)
+	if err != nil {
+		return false, err
+	}
+
+	nsSelector, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return false, err
+	}
+	return nsSelector.Matches(labels.Set(ns.Labels)), nil
+}
+
+// matchingClusters applies ClusterAffinity and then SpreadConstraints to the
+// full list of joined RemoteClusters.
+func matchingClusters(placement networkingv1.Placement, remoteClusters []*networkingv1.RemoteCluster) []*networkingv1.RemoteCluster {
+	if placement.ClusterAffinity == nil {
+		return nil
+	}
+	affinity := placement.ClusterAffinity
+
+	exclude := make(map[string]struct{}, len(affinity.Exclude))
+	for _, name := range affinity.Exclude {
+		exclude[name] = struct{}{}
+	}
+	allow := make(map[string]struct{}, len(affinity.ClusterNames))
+	for _, name := range affinity.ClusterNames {
+		allow[name] = struct{}{}
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(affinity.LabelSelector)
+	if err != nil {
+		selector = labels.Everything()
+	}
+
+	var matched []*networkingv1.RemoteCluster
+	for _, rc := range remoteClusters {
+		if _, excluded := exclude[rc.Name]; excluded {
+			continue
+		}
+		if len(allow) > 0 {
+			if _, ok := allow[rc.Name]; !ok {
+				continue
+			}
+		} else if affinity.LabelSelector != nil && !selector.Matches(labels.Set(rc.Labels)) {
+			continue
+		}
+		matched = append(matched, rc)
+	}
+
+	if placement.SpreadConstraints != nil && placement.SpreadConstraints.MaxClusters > 0 &&
+		int32(len(matched)) > placement.SpreadConstraints.MaxClusters {
+		matched = matched[:placement.SpreadConstraints.MaxClusters]
+	}
+
+	return matched
+}
+
+// propagate ensures a RemoteSubnet/RemoteVtep mirroring resource exists in
+// target, reporting whether it is currently applied. pk identifies the
+// policy driving this propagation and is stamped onto the created/updated
+// object so reconcileLastApplied can find it again after a restart.
+func (c *Controller) propagate(kind networkingv1.ResourceKind, resource matchedResource, target *networkingv1.RemoteCluster, pk policyKey) (bool, string) {
+	_, remoteHybridnetClient, err := c.clusterCache.GetClient(target.Name)
+	if err != nil {
+		return false, err.Error()
+	}
+
+	switch kind {
+	case networkingv1.ResourceKindSubnet:
+		if err := ensureRemoteSubnet(remoteHybridnetClient, resource, pk); err != nil {
+			return false, err.Error()
+		}
+	case networkingv1.ResourceKindVtep:
+		if err := ensureRemoteVtep(remoteHybridnetClient, resource, pk); err != nil {
+			return false, err.Error()
+		}
+	case networkingv1.ResourceKindNetwork:
+		// Network selectors only scope which subnets are exported; there is
+		// no standalone RemoteNetwork object to create, so report this tuple
+		// as not applied rather than claiming a propagation that never
+		// happened.
+		return false, "network selectors do not propagate a standalone object"
+	default:
+		return false, fmt.Sprintf("propagation: unsupported resource kind %q", kind)
+	}
+	return true, ""
+}
+
+// convertSpec copies src (a SubnetSpec/VtepSpec-shaped value) onto dst (a
+// *RemoteSubnetSpec/*RemoteVtepSpec) by round-tripping through JSON, so the
+// two sides only need to agree on field tags, not on being the same Go type.
+func convertSpec(src interface{}, dst interface{}) error {
+	data, err := json.Marshal(src)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, dst)
+}
+
+// withOwnershipLabels returns resourceLabels plus the labels that identify
+// which policy propagated an object and, for namespaced sources like Vtep,
+// which namespace it came from.
+func withOwnershipLabels(resourceLabels map[string]string, pk policyKey, sourceNamespace string) map[string]string {
+	out := make(map[string]string, len(resourceLabels)+2)
+	for k, v := range resourceLabels {
+		out[k] = v
+	}
+	out[PropagationPolicyLabel] = pk.name
+	if pk.namespace != "" {
+		out[PropagationPolicyNamespaceLabel] = pk.namespace
+	}
+	if sourceNamespace != "" {
+		out[PropagationSourceNamespaceLabel] = sourceNamespace
+	}
+	return out
+}
+
+func ensureRemoteSubnet(client versioned.Interface, resource matchedResource, pk policyKey) error {
+	var desiredSpec networkingv1.RemoteSubnetSpec
+	if err := convertSpec(resource.Spec, &desiredSpec); err != nil {
+		return fmt.Errorf("propagation: can't convert spec for subnet %s: %w", resource.Name, err)
+	}
+	desiredLabels := withOwnershipLabels(resource.Labels, pk, resource.Namespace)
+
+	existing, err := client.NetworkingV1().RemoteSubnets().Get(context.TODO(), resource.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		_, err = client.NetworkingV1().RemoteSubnets().Create(context.TODO(), &networkingv1.RemoteSubnet{
+			ObjectMeta: metav1.ObjectMeta{Name: resource.Name, Labels: desiredLabels},
+			Spec:       desiredSpec,
+		}, metav1.CreateOptions{})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	if reflect.DeepEqual(existing.Labels, desiredLabels) && reflect.DeepEqual(existing.Spec, desiredSpec) {
+		return nil
+	}
+
+	updated := existing.DeepCopy()
+	updated.Labels = desiredLabels
+	updated.Spec = desiredSpec
+	_, err = client.NetworkingV1().RemoteSubnets().Update(context.TODO(), updated, metav1.UpdateOptions{})
+	return err
+}
+
+func ensureRemoteVtep(client versioned.Interface, resource matchedResource, pk policyKey) error {
+	var desiredSpec networkingv1.RemoteVtepSpec
+	if err := convertSpec(resource.Spec, &desiredSpec); err != nil {
+		return fmt.Errorf("propagation: can't convert spec for vtep %s: %w", resource.Name, err)
+	}
+	desiredLabels := withOwnershipLabels(resource.Labels, pk, resource.Namespace)
+
+	existing, err := client.NetworkingV1().RemoteVteps().Get(context.TODO(), resource.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		_, err = client.NetworkingV1().RemoteVteps().Create(context.TODO(), &networkingv1.RemoteVtep{
+			ObjectMeta: metav1.ObjectMeta{Name: resource.Name, Labels: desiredLabels},
+			Spec:       desiredSpec,
+		}, metav1.CreateOptions{})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	if reflect.DeepEqual(existing.Labels, desiredLabels) && reflect.DeepEqual(existing.Spec, desiredSpec) {
+		return nil
+	}
+
+	updated := existing.DeepCopy()
+	updated.Labels = desiredLabels
+	updated.Spec = desiredSpec
+	_, err = client.NetworkingV1().RemoteVteps().Update(context.TODO(), updated, metav1.UpdateOptions{})
+	return err
+}
+
+func (c *Controller) getPolicy(pk policyKey) (networkingv1.RemoteClusterPropagationPolicySpec, func([]networkingv1.AppliedResource) error, error) {
+	if pk.namespace == "" {
+		policy, err := c.clusterPolicyLister.Get(pk.name)
+		if err != nil {
+			return networkingv1.RemoteClusterPropagationPolicySpec{}, nil, err
+		}
+		return policy.Spec, func(applied []networkingv1.AppliedResource) error {
+			updated := policy.DeepCopy()
+			updated.Status.AppliedResources = applied
+			_, err := c.hybridnetClient.NetworkingV1().ClusterRemoteClusterPropagationPolicies().UpdateStatus(context.TODO(), updated, metav1.UpdateOptions{})
+			return err
+		}, nil
+	}
+
+	policy, err := c.policyLister.RemoteClusterPropagationPolicies(pk.namespace).Get(pk.name)
+	if err != nil {
+		return networkingv1.RemoteClusterPropagationPolicySpec{}, nil, err
+	}
+	return policy.Spec, func(applied []networkingv1.AppliedResource) error {
+		updated := policy.DeepCopy()
+		updated.Status.AppliedResources = applied
+		_, err := c.hybridnetClient.NetworkingV1().RemoteClusterPropagationPolicies(pk.namespace).UpdateStatus(context.TODO(), updated, metav1.UpdateOptions{})
+		return err
+	}, nil
+}
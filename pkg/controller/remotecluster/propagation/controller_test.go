@@ -0,0 +1,88 @@
+/*
+ Copyright 2021 The Hybridnet Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package propagation
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	networkingv1 "github.com/alibaba/hybridnet/pkg/apis/networking/v1"
+)
+
+func remoteCluster(name string, labels map[string]string) *networkingv1.RemoteCluster {
+	return &networkingv1.RemoteCluster{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+}
+
+func TestMatchingClustersRequiresClusterAffinity(t *testing.T) {
+	clusters := []*networkingv1.RemoteCluster{remoteCluster("a", nil)}
+	if matched := matchingClusters(networkingv1.Placement{}, clusters); matched != nil {
+		t.Fatalf("expected a Placement with no ClusterAffinity to match nothing, got %v", matched)
+	}
+}
+
+func TestMatchingClustersExcludeWinsOverClusterNames(t *testing.T) {
+	clusters := []*networkingv1.RemoteCluster{
+		remoteCluster("a", nil),
+		remoteCluster("b", nil),
+	}
+	placement := networkingv1.Placement{
+		ClusterAffinity: &networkingv1.ClusterAffinity{
+			ClusterNames: []string{"a", "b"},
+			Exclude:      []string{"b"},
+		},
+	}
+
+	matched := matchingClusters(placement, clusters)
+	if len(matched) != 1 || matched[0].Name != "a" {
+		t.Fatalf("expected only cluster a to match, got %v", matched)
+	}
+}
+
+func TestMatchingClustersLabelSelector(t *testing.T) {
+	clusters := []*networkingv1.RemoteCluster{
+		remoteCluster("a", map[string]string{"env": "prod"}),
+		remoteCluster("b", map[string]string{"env": "dev"}),
+	}
+	placement := networkingv1.Placement{
+		ClusterAffinity: &networkingv1.ClusterAffinity{
+			LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+		},
+	}
+
+	matched := matchingClusters(placement, clusters)
+	if len(matched) != 1 || matched[0].Name != "a" {
+		t.Fatalf("expected only the prod-labelled cluster to match, got %v", matched)
+	}
+}
+
+func TestMatchingClustersSpreadConstraintCapsCount(t *testing.T) {
+	clusters := []*networkingv1.RemoteCluster{
+		remoteCluster("a", nil),
+		remoteCluster("b", nil),
+		remoteCluster("c", nil),
+	}
+	placement := networkingv1.Placement{
+		ClusterAffinity:   &networkingv1.ClusterAffinity{},
+		SpreadConstraints: &networkingv1.SpreadConstraint{MaxClusters: 2},
+	}
+
+	matched := matchingClusters(placement, clusters)
+	if len(matched) != 2 {
+		t.Fatalf("expected SpreadConstraints.MaxClusters to cap matches at 2, got %d", len(matched))
+	}
+}
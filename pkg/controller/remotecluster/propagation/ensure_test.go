@@ -0,0 +1,190 @@
+/*
+ Copyright 2021 The Hybridnet Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package propagation
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubetesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+
+	corelisters "k8s.io/client-go/listers/core/v1"
+
+	networkingv1 "github.com/alibaba/hybridnet/pkg/apis/networking/v1"
+	hybridnetfake "github.com/alibaba/hybridnet/pkg/client/clientset/versioned/fake"
+	clustercachefake "github.com/alibaba/hybridnet/pkg/controller/remotecluster/clustercache/fake"
+)
+
+func TestEnsureRemoteSubnetCreatesThenNoopsThenUpdates(t *testing.T) {
+	client := hybridnetfake.NewSimpleClientset()
+	pk := policyKey{name: "policy-a"}
+	resource := matchedResource{Name: "subnet-a", Labels: map[string]string{"team": "a"}, Spec: map[string]interface{}{}}
+
+	if err := ensureRemoteSubnet(client, resource, pk); err != nil {
+		t.Fatalf("expected create to succeed, got %v", err)
+	}
+
+	created, err := client.NetworkingV1().RemoteSubnets().Get(context.TODO(), "subnet-a", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected the RemoteSubnet to exist after ensureRemoteSubnet, got %v", err)
+	}
+	if created.Labels[PropagationPolicyLabel] != "policy-a" {
+		t.Fatalf("expected the created RemoteSubnet to carry PropagationPolicyLabel, got %v", created.Labels)
+	}
+	if created.Labels["team"] != "a" {
+		t.Fatalf("expected the source's own labels to be carried over, got %v", created.Labels)
+	}
+
+	expectNoUpdate := true
+	client.PrependReactor("update", "remotesubnets", func(kubetesting.Action) (bool, runtime.Object, error) {
+		if expectNoUpdate {
+			t.Fatal("expected a second call against an unchanged resource to no-op, not issue an Update")
+		}
+		return false, nil, nil
+	})
+	if err := ensureRemoteSubnet(client, resource, pk); err != nil {
+		t.Fatalf("expected the no-op call to succeed, got %v", err)
+	}
+	expectNoUpdate = false
+
+	changed := matchedResource{Name: "subnet-a", Labels: map[string]string{"team": "b"}, Spec: map[string]interface{}{}}
+	if err := ensureRemoteSubnet(client, changed, pk); err != nil {
+		t.Fatalf("expected an update for a drifted label to succeed, got %v", err)
+	}
+	updated, err := client.NetworkingV1().RemoteSubnets().Get(context.TODO(), "subnet-a", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected the RemoteSubnet to still exist, got %v", err)
+	}
+	if updated.Labels["team"] != "b" {
+		t.Fatalf("expected the drifted label to be applied, got %v", updated.Labels)
+	}
+}
+
+func TestEnsureRemoteVtepStampsSourceNamespace(t *testing.T) {
+	client := hybridnetfake.NewSimpleClientset()
+	pk := policyKey{namespace: "team-a", name: "policy-a"}
+	resource := matchedResource{Name: "vtep-a", Namespace: "source-ns", Spec: map[string]interface{}{}}
+
+	if err := ensureRemoteVtep(client, resource, pk); err != nil {
+		t.Fatalf("expected create to succeed, got %v", err)
+	}
+
+	created, err := client.NetworkingV1().RemoteVteps().Get(context.TODO(), "vtep-a", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected the RemoteVtep to exist after ensureRemoteVtep, got %v", err)
+	}
+	if created.Labels[PropagationPolicyNamespaceLabel] != "team-a" {
+		t.Fatalf("expected a namespace-scoped policy owner to stamp PropagationPolicyNamespaceLabel, got %v", created.Labels)
+	}
+	if created.Labels[PropagationSourceNamespaceLabel] != "source-ns" {
+		t.Fatalf("expected the source Vtep's own namespace to be stamped, got %v", created.Labels)
+	}
+}
+
+func TestRemoveStaleResourcesDeletesWhatStoppedMatching(t *testing.T) {
+	cache := clustercachefake.New()
+	cache.AddCluster("target")
+	_, remoteClient, err := cache.GetClient("target")
+	if err != nil {
+		t.Fatalf("expected the fake cluster cache to connect, got %v", err)
+	}
+	if _, err := remoteClient.NetworkingV1().RemoteSubnets().Create(context.TODO(), &networkingv1.RemoteSubnet{
+		ObjectMeta: metav1.ObjectMeta{Name: "subnet-a"},
+	}, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("expected seeding the target cluster to succeed, got %v", err)
+	}
+
+	c := &Controller{clusterCache: cache, lastApplied: make(map[policyKey][]networkingv1.AppliedResource)}
+	pk := policyKey{name: "policy-a"}
+
+	firstApplied := []networkingv1.AppliedResource{
+		{Kind: networkingv1.ResourceKindSubnet, Name: "subnet-a", ClusterName: "target", Applied: true},
+	}
+	c.removeStaleResources(pk, firstApplied)
+	if _, err := remoteClient.NetworkingV1().RemoteSubnets().Get(context.TODO(), "subnet-a", metav1.GetOptions{}); err != nil {
+		t.Fatalf("expected subnet-a to still exist after a sync where it's still applied, got %v", err)
+	}
+
+	// subnet-a stopped matching on the next sync: it should be cleaned up
+	// from the target cluster, and lastApplied should reflect the new
+	// (empty) set.
+	c.removeStaleResources(pk, nil)
+	if _, err := remoteClient.NetworkingV1().RemoteSubnets().Get(context.TODO(), "subnet-a", metav1.GetOptions{}); err == nil {
+		t.Fatal("expected subnet-a to be deleted once it stopped matching")
+	}
+}
+
+func TestCleanupPolicyDeletesEverythingThePolicyLastApplied(t *testing.T) {
+	cache := clustercachefake.New()
+	cache.AddCluster("target")
+	_, remoteClient, _ := cache.GetClient("target")
+	_, _ = remoteClient.NetworkingV1().RemoteVteps().Create(context.TODO(), &networkingv1.RemoteVtep{
+		ObjectMeta: metav1.ObjectMeta{Name: "vtep-a"},
+	}, metav1.CreateOptions{})
+
+	pk := policyKey{name: "policy-a"}
+	c := &Controller{
+		clusterCache: cache,
+		lastApplied: map[policyKey][]networkingv1.AppliedResource{
+			pk: {{Kind: networkingv1.ResourceKindVtep, Name: "vtep-a", ClusterName: "target", Applied: true}},
+		},
+	}
+
+	c.cleanupPolicy(pk)
+
+	if _, ok := c.lastApplied[pk]; ok {
+		t.Fatal("expected cleanupPolicy to forget pk from lastApplied")
+	}
+	if _, err := remoteClient.NetworkingV1().RemoteVteps().Get(context.TODO(), "vtep-a", metav1.GetOptions{}); err == nil {
+		t.Fatal("expected cleanupPolicy to delete vtep-a from the target cluster")
+	}
+}
+
+func newTestNamespaceLister(namespaces ...*corev1.Namespace) corelisters.NamespaceLister {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, ns := range namespaces {
+		_ = indexer.Add(ns)
+	}
+	return corelisters.NewNamespaceLister(indexer)
+}
+
+func TestNamespaceMatches(t *testing.T) {
+	c := &Controller{namespaceLister: newTestNamespaceLister(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "prod", Labels: map[string]string{"env": "prod"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "dev", Labels: map[string]string{"env": "dev"}}},
+	)}
+
+	if matches, err := c.namespaceMatches(nil, "dev"); err != nil || !matches {
+		t.Fatalf("expected a nil selector to match any namespace, got %v, %v", matches, err)
+	}
+
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}}
+	if matches, err := c.namespaceMatches(selector, "prod"); err != nil || !matches {
+		t.Fatalf("expected the prod namespace to match its own label, got %v, %v", matches, err)
+	}
+	if matches, err := c.namespaceMatches(selector, "dev"); err != nil || matches {
+		t.Fatalf("expected the dev namespace not to match a prod selector, got %v, %v", matches, err)
+	}
+
+	if _, err := c.namespaceMatches(selector, "missing"); err == nil {
+		t.Fatal("expected an unknown namespace to surface a lookup error")
+	}
+}
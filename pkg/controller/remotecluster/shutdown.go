@@ -0,0 +1,162 @@
+/*
+ Copyright 2021 The Hybridnet Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package remotecluster
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog"
+
+	networkingv1 "github.com/alibaba/hybridnet/pkg/apis/networking/v1"
+	"github.com/alibaba/hybridnet/pkg/controller/remotecluster/clustercache"
+	rctypes "github.com/alibaba/hybridnet/pkg/controller/remotecluster/types"
+)
+
+// ConditionTerminating is patched onto a RemoteCluster's status while this
+// controller instance is shutting down, so the next controller to pick up
+// the lock (e.g. the new pod of a rolling upgrade) can tell the old UUID
+// lock was released deliberately rather than having gone stale.
+const ConditionTerminating = "Terminating"
+
+// DefaultDrainTimeout bounds how long Run's internal call to Shutdown waits
+// when stopCh closes without a caller-supplied deadline. Callers that have a
+// SIGTERM-derived deadline should call Shutdown directly instead.
+const DefaultDrainTimeout = 15 * time.Second
+
+// emitEvent sends event to remoteClusterEvent unless Shutdown has already
+// marked events closed, in which case the event is dropped: nothing
+// downstream of a draining controller can act on it anyway. It is the only
+// thing that respects eventsClosed, so any producer of rctypes.Event must go
+// through it rather than sending on remoteClusterEvent directly.
+func (c *Controller) emitEvent(event rctypes.Event) {
+	c.eventMu.RLock()
+	defer c.eventMu.RUnlock()
+
+	if c.eventsClosed {
+		klog.V(4).Infof("[remote cluster] dropping event for %s, controller is shutting down", event.ClusterName)
+		return
+	}
+	c.remoteClusterEvent <- event
+}
+
+// Shutdown stops accepting new remote cluster events, drains whatever is
+// already queued, releases every cached cluster's UUID lock and patches a
+// Terminating condition onto its RemoteCluster, and waits for the current
+// health-check pass to finish. It is meant to be called with a
+// SIGTERM-derived deadline, as an alternative to relying solely on stopCh
+// closing, so a rolling upgrade's incoming pod does not have to wait out the
+// UUID lock's own timeout before it can take over.
+func (c *Controller) Shutdown(ctx context.Context) error {
+	var shutdownErr error
+
+	c.shutdownOnce.Do(func() {
+		c.eventMu.Lock()
+		c.eventsClosed = true
+		c.eventMu.Unlock()
+		close(c.eventStopCh)
+
+		select {
+		case <-c.eventDrained:
+		case <-ctx.Done():
+			shutdownErr = fmt.Errorf("remotecluster: timed out draining event queue: %w", ctx.Err())
+		}
+
+		c.clusterCache.Range(func(clusterName string, _ *clustercache.Accessor) bool {
+			if err := c.releaseRemoteCluster(clusterName); err != nil {
+				klog.Warningf("[remote cluster] failed to release %s during shutdown: %v", clusterName, err)
+			}
+			return true
+		})
+		c.clusterCache.RemoveAll()
+
+		healthDone := make(chan struct{})
+		go func() {
+			c.healthWG.Wait()
+			close(healthDone)
+		}()
+
+		select {
+		case <-healthDone:
+		case <-ctx.Done():
+			if shutdownErr == nil {
+				shutdownErr = fmt.Errorf("remotecluster: timed out waiting for health checks to finish: %w", ctx.Err())
+			}
+		}
+	})
+
+	return shutdownErr
+}
+
+// releaseRemoteCluster sets a Terminating condition onto clusterName's
+// RemoteCluster and releases its UUID lock, so a successor controller
+// instance does not have to wait out the lock's own timeout to take over.
+//
+// This does a read-modify-write through UpdateStatus rather than a merge
+// patch: status.conditions is an array, and a JSON merge patch replaces an
+// array wholesale instead of merging it, which would silently wipe out every
+// other condition (e.g. Healthy, Joined) already recorded on the object.
+func (c *Controller) releaseRemoteCluster(clusterName string) error {
+	remoteCluster, err := c.remoteClusterLister.Get(clusterName)
+	if err != nil {
+		return err
+	}
+
+	if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		current, getErr := c.hybridnetClient.NetworkingV1().RemoteClusters().Get(context.TODO(), clusterName, metav1.GetOptions{})
+		if getErr != nil {
+			return getErr
+		}
+
+		updated := current.DeepCopy()
+		setRemoteClusterCondition(updated, ConditionTerminating)
+
+		_, updateErr := c.hybridnetClient.NetworkingV1().RemoteClusters().UpdateStatus(context.TODO(), updated, metav1.UpdateOptions{})
+		return updateErr
+	}); err != nil {
+		return err
+	}
+
+	if len(remoteCluster.Status.UUID) > 0 {
+		c.remoteClusterUUIDLock.Unlock(remoteCluster.Status.UUID)
+	}
+	return nil
+}
+
+// setRemoteClusterCondition replaces the condition of type conditionType in
+// place if one already exists, preserving every other condition, or appends
+// a new one otherwise.
+func setRemoteClusterCondition(remoteCluster *networkingv1.RemoteCluster, conditionType string) {
+	now := metav1.Now()
+	for i := range remoteCluster.Status.Conditions {
+		if string(remoteCluster.Status.Conditions[i].Type) == conditionType {
+			remoteCluster.Status.Conditions[i].Status = corev1.ConditionTrue
+			remoteCluster.Status.Conditions[i].LastTransitionTime = now
+			return
+		}
+	}
+
+	remoteCluster.Status.Conditions = append(remoteCluster.Status.Conditions, networkingv1.RemoteClusterCondition{
+		Type:               networkingv1.RemoteClusterConditionType(conditionType),
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: now,
+	})
+}